@@ -0,0 +1,190 @@
+package hw02unpackstring
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"math"
+)
+
+// decoder - конечный автомат распаковки, общий для Unpack, UnpackWithOptions и Unpacker.
+type decoder struct {
+	w       *bufio.Writer
+	opts    Options
+	escape  rune
+	limit   int // Ограничение размера вывода, ноль - без ограничения
+	written int
+
+	pending    []rune // Руна (или графемный кластер), ожидающая возможного счетчика повторов
+	hasPending bool
+	cluster    clusterBreaker
+	escaped    bool // Предыдущая руна - неэкранированный символ экранирования
+	escapeAt   int
+	count      int  // Набираемый многозначный счетчик
+	inCount    bool // Счетчик начат, но еще не применен
+	inBraces   bool
+	braceAt    int
+}
+
+func newDecoder(w *bufio.Writer, opts Options) *decoder {
+	return &decoder{w: w, opts: opts, escape: opts.escapeRune(), limit: opts.outputLimit()}
+}
+
+func (d *decoder) decode(r io.RuneReader) error {
+	for offset := 0; ; offset++ {
+		currRune, _, err := r.ReadRune()
+		if errors.Is(err, io.EOF) {
+			return d.finish()
+		}
+		if err != nil {
+			return err
+		}
+		if err := d.step(currRune, offset); err != nil {
+			return err
+		}
+	}
+}
+
+func (d *decoder) step(currRune rune, offset int) error {
+	switch {
+	// Экранировать можно только цифру, символ экранирования или фигурную скобку
+	case d.escaped:
+		if !d.isEscapable(currRune) {
+			return &SyntaxError{Offset: offset, Rune: currRune, Reason: ReasonInvalidEscape}
+		}
+		d.escaped = false
+		d.startPending(currRune)
+	// Внутри скобок допустимы только цифры и закрывающая скобка
+	case d.inBraces:
+		switch {
+		case runeIsDigit(currRune):
+			return d.addDigit(currRune)
+		case currRune == '}' && d.inCount:
+			d.inBraces = false
+			return d.flushCount()
+		default:
+			return &SyntaxError{Offset: offset, Rune: currRune, Reason: ReasonInvalidBrace}
+		}
+	// Продолжение многозначного счетчика
+	case runeIsDigit(currRune) && d.inCount:
+		return d.addDigit(currRune)
+	// Цифра - счетчик повторов для ожидающей руны
+	case runeIsDigit(currRune):
+		if !d.hasPending {
+			return &SyntaxError{Offset: offset, Rune: currRune, Reason: digitReason(offset)}
+		}
+		if d.opts.MultiDigit {
+			return d.addDigit(currRune)
+		}
+		d.hasPending = false
+		return d.writeRepeated(int(currRune - '0'))
+	case d.opts.Braces && currRune == '{':
+		if !d.hasPending || d.inCount {
+			return &SyntaxError{Offset: offset, Rune: currRune, Reason: ReasonInvalidBrace}
+		}
+		d.inBraces, d.braceAt = true, offset
+	case d.opts.Braces && currRune == '}':
+		return &SyntaxError{Offset: offset, Rune: currRune, Reason: ReasonInvalidBrace}
+	// В графемном режиме руна может продолжать ожидающий кластер
+	case d.opts.Graphemes && d.hasPending && !d.inCount && d.cluster.extends(currRune):
+		d.pending = append(d.pending, currRune)
+	default:
+		if err := d.flushPending(); err != nil {
+			return err
+		}
+		if currRune == d.escape {
+			d.escaped, d.escapeAt = true, offset
+			return nil
+		}
+		d.startPending(currRune)
+	}
+	return nil
+}
+
+func (d *decoder) startPending(r rune) {
+	d.pending = append(d.pending[:0], r)
+	d.hasPending = true
+	if d.opts.Graphemes {
+		d.cluster.reset()
+		d.cluster.extends(r)
+	}
+}
+
+func (d *decoder) finish() error {
+	// Строка не может заканчиваться незавершенным экранированием или открытой скобкой
+	if d.escaped {
+		return &SyntaxError{Offset: d.escapeAt, Rune: d.escape, Reason: ReasonDanglingEscape}
+	}
+	if d.inBraces {
+		return &SyntaxError{Offset: d.braceAt, Rune: '{', Reason: ReasonUnclosedBrace}
+	}
+	return d.flushPending()
+}
+
+func (d *decoder) isEscapable(r rune) bool {
+	return r == d.escape || runeIsDigit(r) || (d.opts.Braces && (r == '{' || r == '}'))
+}
+
+func (d *decoder) addDigit(r rune) error {
+	d.count = d.count*10 + int(r-'0')
+	d.inCount = true
+	// Проверяем размер заранее, чтобы не набирать заведомо слишком большой счетчик
+	if d.count > math.MaxInt32 || d.exceedsLimit(d.count) {
+		return ErrOutputTooLarge
+	}
+	return nil
+}
+
+// flushPending записывает ожидающую руну с набранным счетчиком или один раз.
+func (d *decoder) flushPending() error {
+	if d.inCount {
+		return d.flushCount()
+	}
+	if !d.hasPending {
+		return nil
+	}
+	d.hasPending = false
+	return d.writeRepeated(1)
+}
+
+func (d *decoder) flushCount() error {
+	count := d.count
+	d.hasPending, d.inCount, d.count = false, false, 0
+	return d.writeRepeated(count)
+}
+
+func (d *decoder) exceedsLimit(count int) bool {
+	if d.limit == 0 {
+		return false
+	}
+	size := 0
+	for _, r := range d.pending {
+		size += len(string(r))
+	}
+	return d.written+count*size > d.limit
+}
+
+// writeRepeated записывает ожидающую руну или кластер count раз.
+func (d *decoder) writeRepeated(count int) error {
+	if d.exceedsLimit(count) {
+		return ErrOutputTooLarge
+	}
+	for range count {
+		for _, r := range d.pending {
+			n, err := d.w.WriteRune(r)
+			d.written += n
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// digitReason определяет причину ошибки для цифры без руны перед ней.
+func digitReason(offset int) SyntaxReason {
+	if offset == 0 {
+		return ReasonLeadingDigit
+	}
+	return ReasonDoubleDigit
+}
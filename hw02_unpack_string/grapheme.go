@@ -0,0 +1,170 @@
+package hw02unpackstring
+
+import "unicode"
+
+// graphemeProperty - упрощенное значение свойства Grapheme_Cluster_Break из UAX #29.
+type graphemeProperty int
+
+const (
+	gpOther graphemeProperty = iota
+	gpCR
+	gpLF
+	gpControl
+	gpExtend
+	gpZWJ
+	gpRegionalIndicator
+	gpSpacingMark
+	gpL
+	gpV
+	gpT
+	gpLV
+	gpLVT
+	gpPictographic
+)
+
+// clusterBreaker находит границы расширенных графемных кластеров по правилам UAX #29.
+// Поддержаны правила GB3-GB9a, GB11 (эмодзи с ZWJ) и GB12/GB13 (флаги); Prepend не поддержан.
+type clusterBreaker struct {
+	started  bool
+	prev     graphemeProperty
+	riCount  int  // Число подряд идущих региональных индикаторов в кластере
+	pictSeen bool // В кластере была последовательность ExtPict Extend* для правила GB11
+}
+
+func (b *clusterBreaker) reset() {
+	*b = clusterBreaker{}
+}
+
+// extends сообщает, продолжает ли r текущий кластер, и запоминает r как последнюю руну.
+func (b *clusterBreaker) extends(r rune) bool {
+	curr := graphemePropertyOf(r)
+	joined := b.started && !isClusterBreak(b.prev, curr, b.pictSeen, b.riCount)
+
+	if curr == gpRegionalIndicator {
+		if !joined {
+			b.riCount = 0
+		}
+		b.riCount++
+	} else {
+		b.riCount = 0
+	}
+
+	switch {
+	case curr == gpPictographic:
+		b.pictSeen = true
+	case curr == gpExtend || curr == gpZWJ:
+		b.pictSeen = b.pictSeen && joined && b.prev != gpZWJ
+	default:
+		b.pictSeen = false
+	}
+
+	b.started, b.prev = true, curr
+	return joined
+}
+
+func isClusterBreak(prev, curr graphemeProperty, pictSeen bool, riCount int) bool {
+	switch {
+	// GB3: CR × LF
+	case prev == gpCR && curr == gpLF:
+		return false
+	// GB4, GB5: разрыв после и перед управляющими символами
+	case prev == gpCR || prev == gpLF || prev == gpControl,
+		curr == gpCR || curr == gpLF || curr == gpControl:
+		return true
+	// GB6-GB8: слоги хангыля
+	case prev == gpL && (curr == gpL || curr == gpV || curr == gpLV || curr == gpLVT),
+		(prev == gpLV || prev == gpV) && (curr == gpV || curr == gpT),
+		(prev == gpLVT || prev == gpT) && curr == gpT:
+		return false
+	// GB9, GB9a: × (Extend | ZWJ | SpacingMark)
+	case curr == gpExtend || curr == gpZWJ || curr == gpSpacingMark:
+		return false
+	// GB11: ExtPict Extend* ZWJ × ExtPict
+	case prev == gpZWJ && curr == gpPictographic && pictSeen:
+		return false
+	// GB12, GB13: региональные индикаторы объединяются парами
+	case prev == gpRegionalIndicator && curr == gpRegionalIndicator:
+		return riCount%2 == 0
+	}
+	// GB999
+	return true
+}
+
+func graphemePropertyOf(r rune) graphemeProperty {
+	switch {
+	case r == '\r':
+		return gpCR
+	case r == '\n':
+		return gpLF
+	case r == '\u200D':
+		return gpZWJ
+	case isGraphemeExtend(r):
+		return gpExtend
+	case unicode.In(r, unicode.Cc, unicode.Cf, unicode.Zl, unicode.Zp):
+		return gpControl
+	case r >= 0x1F1E6 && r <= 0x1F1FF:
+		return gpRegionalIndicator
+	case unicode.Is(unicode.Mc, r):
+		return gpSpacingMark
+	case isPictographic(r):
+		return gpPictographic
+	}
+	return hangulProperty(r)
+}
+
+func isGraphemeExtend(r rune) bool {
+	return unicode.In(r, unicode.Mn, unicode.Me) ||
+		r == '\u200C' || // ZWNJ
+		(r >= 0x1F3FB && r <= 0x1F3FF) || // Модификаторы цвета кожи
+		(r >= 0xE0020 && r <= 0xE007F) // Теги для флагов регионов
+}
+
+// pictographicRanges - приближение свойства Extended_Pictographic основными блоками эмодзи.
+var pictographicRanges = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{Lo: 0x00A9, Hi: 0x00AE, Stride: 5},
+		{Lo: 0x203C, Hi: 0x2049, Stride: 13},
+		{Lo: 0x2122, Hi: 0x2139, Stride: 23},
+		{Lo: 0x2194, Hi: 0x21AA, Stride: 1},
+		{Lo: 0x231A, Hi: 0x23FF, Stride: 1},
+		{Lo: 0x24C2, Hi: 0x24C2, Stride: 1},
+		{Lo: 0x25AA, Hi: 0x25FE, Stride: 1},
+		{Lo: 0x2600, Hi: 0x27BF, Stride: 1},
+		{Lo: 0x2934, Hi: 0x2935, Stride: 1},
+		{Lo: 0x2B05, Hi: 0x2B55, Stride: 1},
+		{Lo: 0x3030, Hi: 0x303D, Stride: 13},
+		{Lo: 0x3297, Hi: 0x3299, Stride: 2},
+	},
+	R32: []unicode.Range32{
+		{Lo: 0x1F000, Hi: 0x1F1E5, Stride: 1},
+		{Lo: 0x1F200, Hi: 0x1F3FA, Stride: 1},
+		{Lo: 0x1F400, Hi: 0x1FAFF, Stride: 1},
+		{Lo: 0x1FC00, Hi: 0x1FFFD, Stride: 1},
+	},
+}
+
+func isPictographic(r rune) bool {
+	return unicode.Is(pictographicRanges, r)
+}
+
+func hangulProperty(r rune) graphemeProperty {
+	const (
+		sBase  = 0xAC00
+		sCount = 11172
+		tCount = 28
+	)
+	switch {
+	case (r >= 0x1100 && r <= 0x115F) || (r >= 0xA960 && r <= 0xA97C):
+		return gpL
+	case (r >= 0x1160 && r <= 0x11A7) || (r >= 0xD7B0 && r <= 0xD7C6):
+		return gpV
+	case (r >= 0x11A8 && r <= 0x11FF) || (r >= 0xD7CB && r <= 0xD7FB):
+		return gpT
+	case r >= sBase && r < sBase+sCount:
+		if (r-sBase)%tCount == 0 {
+			return gpLV
+		}
+		return gpLVT
+	}
+	return gpOther
+}
@@ -0,0 +1,69 @@
+package hw02unpackstring
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnpackGraphemes(t *testing.T) {
+	const (
+		eAcute     = "e\u0301"                                                        // e + combining acute accent
+		stacked    = "a\u0323\u0301\u0308"                                            // several combining marks
+		family     = "\U0001F468\u200D\U0001F469\u200D\U0001F467"                     // ZWJ sequence
+		thumbsUp   = "\U0001F44D\U0001F3FD"                                           // emoji + skin tone modifier
+		handshake  = "\U0001F9D1\U0001F3FB\u200D\U0001F91D\u200D\U0001F9D1\U0001F3FF" // ZWJ sequence with modifiers
+		flagRU     = "\U0001F1F7\U0001F1FA"                                           // regional indicator pair
+		flagUS     = "\U0001F1FA\U0001F1F8"
+		flagScot   = "\U0001F3F4\U000E0067\U000E0062\U000E0073\U000E0063\U000E0074\U000E007F" // tag sequence
+		hangulJamo = "\u1100\u1161\u11A8"                                                     // L V T
+		keycap     = "4\uFE0F\u20E3"                                                          // keycap sequence
+	)
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "combining mark", input: eAcute + "3", expected: strings.Repeat(eAcute, 3)},
+		{name: "several combining marks", input: stacked + "2b", expected: stacked + stacked + "b"},
+		{name: "combining mark zero", input: "x" + eAcute + "0y", expected: "xy"},
+		{name: "zwj family", input: family + "2", expected: family + family},
+		{name: "skin tone", input: thumbsUp + "3", expected: strings.Repeat(thumbsUp, 3)},
+		{name: "zwj with skin tones", input: handshake + "2", expected: handshake + handshake},
+		{name: "flag", input: flagRU + "3", expected: strings.Repeat(flagRU, 3)},
+		{name: "consecutive flags", input: flagRU + flagUS + "2", expected: flagRU + flagUS + flagUS},
+		{name: "odd regional indicator", input: flagRU + "\U0001F1F7" + "2", expected: flagRU + "\U0001F1F7\U0001F1F7"},
+		{name: "tag sequence flag", input: flagScot + "2", expected: flagScot + flagScot},
+		{name: "hangul jamo", input: hangulJamo + "2", expected: hangulJamo + hangulJamo},
+		{name: "crlf", input: "a\r\n3", expected: "a\r\n\r\n\r\n"},
+		{name: "escaped digit with keycap", input: `\` + keycap + "2", expected: keycap + keycap},
+		{name: "plain runes", input: "a4bc2d5e", expected: "aaaabccddddde"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := UnpackWithOptions(tc.input, Options{Graphemes: true})
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, result)
+		})
+	}
+
+	t.Run("rune mode repeats only the last rune", func(t *testing.T) {
+		result, err := Unpack(eAcute + "3")
+		require.NoError(t, err)
+		require.Equal(t, "e\u0301\u0301\u0301", result)
+	})
+
+	t.Run("graphemes with multi digit counts", func(t *testing.T) {
+		result, err := UnpackWithOptions(flagRU+"{12}", Options{Graphemes: true, Braces: true})
+		require.NoError(t, err)
+		require.Equal(t, strings.Repeat(flagRU, 12), result)
+	})
+
+	t.Run("output limit counts the whole cluster", func(t *testing.T) {
+		_, err := UnpackWithOptions(family+"9", Options{Graphemes: true, MaxOutputSize: 100})
+		require.ErrorIs(t, err, ErrOutputTooLarge)
+	})
+}
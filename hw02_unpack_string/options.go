@@ -0,0 +1,73 @@
+package hw02unpackstring
+
+import (
+	"errors"
+	"strings"
+)
+
+const defaultEscapeRune = '\\'
+
+// DefaultMaxOutputSize is the output limit of dialects with multi-digit counts
+// when Options.MaxOutputSize is zero.
+const DefaultMaxOutputSize = 64 << 20
+
+var (
+	ErrOutputTooLarge = errors.New("unpacked string exceeds maximum output size")
+	ErrInvalidOptions = errors.New("invalid unpack options")
+)
+
+// Options describes an unpacking dialect. The zero value is the dialect of Unpack.
+type Options struct {
+	// MultiDigit allows counts of several digits: "a12" => twelve 'a'.
+	MultiDigit bool
+	// Braces allows counts in braces: "a{12}" => twelve 'a'. Literal braces must be escaped.
+	Braces bool
+	// Escape is the escape rune, '\' if zero.
+	Escape rune
+	// Graphemes makes counts repeat whole extended grapheme clusters instead of single runes,
+	// so "e\u0301" followed by "3" yields three accented letters.
+	Graphemes bool
+	// MaxOutputSize limits the unpacked size in bytes. Zero means DefaultMaxOutputSize when
+	// MultiDigit or Braces is set and no limit otherwise: a single digit repeats a rune at most
+	// nine times, so the output stays proportional to the input. Negative means no limit.
+	MaxOutputSize int
+}
+
+// UnpackWithOptions unpacks str using the dialect described by opts.
+func UnpackWithOptions(str string, opts Options) (string, error) {
+	builder := strings.Builder{}
+
+	// Распаковываем тем же автоматом, что и потоковый Unpacker
+	if _, err := NewUnpackerWithOptions(strings.NewReader(str), opts).WriteTo(&builder); err != nil {
+		return "", err
+	}
+	return builder.String(), nil
+}
+
+func (o Options) escapeRune() rune {
+	if o.Escape == 0 {
+		return defaultEscapeRune
+	}
+	return o.Escape
+}
+
+func (o Options) validate() error {
+	escape := o.escapeRune()
+	// Символ экранирования не может совпадать со служебными символами диалекта
+	if runeIsDigit(escape) || (o.Braces && (escape == '{' || escape == '}')) {
+		return ErrInvalidOptions
+	}
+	return nil
+}
+
+// outputLimit возвращает ограничение размера вывода в байтах, ноль - без ограничения.
+func (o Options) outputLimit() int {
+	switch {
+	case o.MaxOutputSize > 0:
+		return o.MaxOutputSize
+	case o.MaxOutputSize == 0 && (o.MultiDigit || o.Braces):
+		// Многозначный счетчик из нескольких символов может запросить гигабайты вывода
+		return DefaultMaxOutputSize
+	}
+	return 0
+}
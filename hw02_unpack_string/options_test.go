@@ -0,0 +1,117 @@
+package hw02unpackstring
+
+import (
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnpackWithOptions(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		opts     Options
+		expected string
+	}{
+		{name: "default dialect", input: `a4bc2d5e\\2`, expected: `aaaabccddddde\\`},
+		{name: "multi digit", input: "a12b", opts: Options{MultiDigit: true}, expected: strings.Repeat("a", 12) + "b"},
+		{name: "multi digit zero", input: "a00b3", opts: Options{MultiDigit: true}, expected: "bbb"},
+		{name: "multi digit escaped", input: `\1\\10`, opts: Options{MultiDigit: true}, expected: `1\\\\\\\\\\`},
+		{name: "braces", input: "a{12}b{0}c", opts: Options{Braces: true}, expected: strings.Repeat("a", 12) + "c"},
+		{name: "braces with digits", input: "a3b{2}", opts: Options{Braces: true}, expected: "aaabb"},
+		{name: "escaped braces", input: `\{2\}`, opts: Options{Braces: true}, expected: "{{}"},
+		{name: "braces are literal by default", input: "a{2}", expected: "a{{}"},
+		{name: "custom escape", input: `/4/5\//`, opts: Options{Escape: '/'}, expected: `45\/`},
+		{name: "custom escape of itself", input: "#42##3", opts: Options{Escape: '#'}, expected: "44###"},
+		{name: "output fits limit", input: "п5", opts: Options{MaxOutputSize: 10}, expected: "ппппп"},
+		{name: "empty", input: "", opts: Options{MultiDigit: true, Braces: true}, expected: ""},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := UnpackWithOptions(tc.input, tc.opts)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, result)
+		})
+	}
+}
+
+func TestUnpackWithOptionsErrors(t *testing.T) {
+	t.Run("syntax errors", func(t *testing.T) {
+		tests := []struct {
+			input  string
+			opts   Options
+			offset int
+			reason SyntaxReason
+		}{
+			{input: "12a", opts: Options{MultiDigit: true}, offset: 0, reason: ReasonLeadingDigit},
+			{input: "a12", opts: Options{}, offset: 2, reason: ReasonDoubleDigit},
+			{input: "{2}", opts: Options{Braces: true}, offset: 0, reason: ReasonInvalidBrace},
+			{input: "a{}", opts: Options{Braces: true}, offset: 2, reason: ReasonInvalidBrace},
+			{input: "a{1b}", opts: Options{Braces: true}, offset: 3, reason: ReasonInvalidBrace},
+			{input: "a}", opts: Options{Braces: true}, offset: 1, reason: ReasonInvalidBrace},
+			{input: "a{12", opts: Options{Braces: true}, offset: 1, reason: ReasonUnclosedBrace},
+			{input: "a/", opts: Options{Escape: '/'}, offset: 1, reason: ReasonDanglingEscape},
+			{input: `a/\`, opts: Options{Escape: '/'}, offset: 2, reason: ReasonInvalidEscape},
+		}
+
+		for _, tc := range tests {
+			t.Run(tc.input, func(t *testing.T) {
+				_, err := UnpackWithOptions(tc.input, tc.opts)
+				require.ErrorIs(t, err, ErrInvalidString)
+
+				var syntaxErr *SyntaxError
+				require.ErrorAs(t, err, &syntaxErr)
+				require.Equal(t, tc.offset, syntaxErr.Offset)
+				require.Equal(t, tc.reason, syntaxErr.Reason)
+			})
+		}
+	})
+
+	t.Run("output too large", func(t *testing.T) {
+		opts := Options{MultiDigit: true, MaxOutputSize: 1 << 10}
+		_, err := UnpackWithOptions("a999999999999999999999", opts)
+		require.ErrorIs(t, err, ErrOutputTooLarge)
+
+		_, err = UnpackWithOptions("п512", opts)
+		require.NoError(t, err)
+		_, err = UnpackWithOptions("п512a", opts)
+		require.ErrorIs(t, err, ErrOutputTooLarge)
+	})
+
+	t.Run("count overflow without limit", func(t *testing.T) {
+		_, err := UnpackWithOptions("a{99999999999999999999}", Options{Braces: true, MaxOutputSize: -1})
+		require.ErrorIs(t, err, ErrOutputTooLarge)
+	})
+
+	t.Run("default limit for multi-digit counts", func(t *testing.T) {
+		var before, after runtime.MemStats
+		runtime.ReadMemStats(&before)
+		_, err := UnpackWithOptions("a2147483647", Options{MultiDigit: true})
+		runtime.ReadMemStats(&after)
+		require.ErrorIs(t, err, ErrOutputTooLarge)
+		// Ошибка должна обнаруживаться до записи вывода, а не после выделения гигабайтов
+		require.Less(t, after.TotalAlloc-before.TotalAlloc, uint64(1<<20))
+
+		_, err = UnpackWithOptions("a{67108865}", Options{Braces: true})
+		require.ErrorIs(t, err, ErrOutputTooLarge)
+		result, err := UnpackWithOptions("a{1000}", Options{Braces: true})
+		require.NoError(t, err)
+		require.Len(t, result, 1000)
+	})
+
+	t.Run("negative limit means no limit", func(t *testing.T) {
+		result, err := UnpackWithOptions("a{1000}", Options{Braces: true, MaxOutputSize: -1})
+		require.NoError(t, err)
+		require.Len(t, result, 1000)
+	})
+
+	t.Run("invalid options", func(t *testing.T) {
+		_, err := UnpackWithOptions("a", Options{Escape: '5'})
+		require.ErrorIs(t, err, ErrInvalidOptions)
+		_, err = UnpackWithOptions("a", Options{Escape: '{', Braces: true})
+		require.ErrorIs(t, err, ErrInvalidOptions)
+	})
+}
@@ -0,0 +1,55 @@
+package hw02unpackstring
+
+import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxRepeats - максимальный счетчик повторов, который понимает Unpack.
+const maxRepeats = 9
+
+var ErrNotUTF8 = errors.New("string is not valid UTF-8")
+
+// Pack returns the shortest packed form of str that Unpack turns back into str.
+// Digits and backslashes are escaped, runs longer than nine runes are split into several groups.
+func Pack(str string) (string, error) {
+	if !utf8.ValidString(str) {
+		return "", ErrNotUTF8
+	}
+
+	builder := strings.Builder{}
+	runes := []rune(str)
+
+	for i := 0; i < len(runes); {
+		// Считаем длину серии одинаковых рун
+		runLen := 1
+		for i+runLen < len(runes) && runes[i+runLen] == runes[i] {
+			runLen++
+		}
+		writeRun(&builder, runes[i], runLen)
+		i += runLen
+	}
+	return builder.String(), nil
+}
+
+// writeRun записывает серию из count рун r группами не длиннее maxRepeats.
+func writeRun(builder *strings.Builder, r rune, count int) {
+	token := string(r)
+	if r == '\\' || runeIsDigit(r) {
+		token = `\` + token
+	}
+
+	for count > 0 {
+		group := min(count, maxRepeats)
+		count -= group
+
+		// Счетчик выгоден, только если он короче повторения токена
+		if len(token)+1 < group*len(token) {
+			builder.WriteString(token)
+			builder.WriteByte(byte('0' + group))
+			continue
+		}
+		builder.WriteString(strings.Repeat(token, group))
+	}
+}
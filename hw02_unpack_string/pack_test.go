@@ -0,0 +1,95 @@
+package hw02unpackstring
+
+import (
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/quick"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPack(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{input: "", expected: ""},
+		{input: "abccd", expected: "abccd"},
+		{input: "aaaabccddddde", expected: "a4bccd5e"},
+		{input: "🙃🙃🙃", expected: "🙃3"},
+		{input: "пп", expected: "п2"},
+		{input: "aaaaaaaaaaaa", expected: "a9a3"},
+		{input: "aaaaaaaaaa", expected: "a9a"},
+		{input: "qwe45", expected: `qwe\4\5`},
+		{input: "qwe44444", expected: `qwe\45`},
+		{input: `\`, expected: `\\`},
+		{input: `\\`, expected: `\\2`},
+		{input: `qwe\3`, expected: `qwe\\\3`},
+		{input: "d\n\n\n\n\nabc", expected: "d\n5abc"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			result, err := Pack(tc.input)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, result)
+		})
+	}
+
+	t.Run("invalid utf-8", func(t *testing.T) {
+		_, err := Pack("a\xffb")
+		require.ErrorIs(t, err, ErrNotUTF8)
+	})
+}
+
+// packInput - строка с длинными сериями, цифрами и слэшами для проверки свойств Pack.
+type packInput string
+
+func (packInput) Generate(rnd *rand.Rand, size int) reflect.Value {
+	alphabet := []rune{'a', 'b', 'z', '0', '5', '9', '\\', '\n', ' ', 'ф', '本', '🙃'}
+	builder := strings.Builder{}
+	for range rnd.Intn(size + 1) {
+		builder.WriteString(strings.Repeat(string(alphabet[rnd.Intn(len(alphabet))]), 1+rnd.Intn(25)))
+	}
+	return reflect.ValueOf(packInput(builder.String()))
+}
+
+func TestPackRoundTrip(t *testing.T) {
+	roundTrip := func(in packInput) bool {
+		packed, err := Pack(string(in))
+		if err != nil {
+			return false
+		}
+		unpacked, err := Unpack(packed)
+		return err == nil && unpacked == string(in)
+	}
+	require.NoError(t, quick.Check(roundTrip, &quick.Config{MaxCount: 2000}))
+
+	// Произвольные строки из quick тоже должны восстанавливаться
+	arbitrary := func(in string) bool {
+		return roundTrip(packInput(in))
+	}
+	require.NoError(t, quick.Check(arbitrary, &quick.Config{MaxCount: 2000}))
+}
+
+func TestPackIsNotLongerThanInput(t *testing.T) {
+	notLonger := func(in packInput) bool {
+		packed, err := Pack(string(in))
+		return err == nil && len(packed) <= len(escapeAll(string(in)))
+	}
+	require.NoError(t, quick.Check(notLonger, nil))
+}
+
+// escapeAll - тривиальная упаковка без счетчиков: только экранирование.
+func escapeAll(str string) string {
+	builder := strings.Builder{}
+	for _, r := range str {
+		if r == '\\' || runeIsDigit(r) {
+			builder.WriteRune('\\')
+		}
+		builder.WriteRune(r)
+	}
+	return builder.String()
+}
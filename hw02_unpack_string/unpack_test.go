@@ -26,6 +26,10 @@ func TestUnpack(t *testing.T) {
 		{input: `qwe\\5`, expected: `qwe\\\\\`},
 		{input: `qwe\\\3`, expected: `qwe\3`},
 		{input: `qwe\4\5q`, expected: `qwe45q`},
+		// Экранированный обратный слэш ведет себя как любая другая руна
+		{input: `a\\b`, expected: `a\b`},
+		{input: `\\\\`, expected: `\\`},
+		{input: `qwe\\`, expected: `qwe\`},
 	}
 
 	for _, tc := range tests {
@@ -39,7 +43,7 @@ func TestUnpack(t *testing.T) {
 }
 
 func TestUnpackInvalidString(t *testing.T) {
-	invalidStrings := []string{"3abc", "45", "aaa10b", `qw\ne`, `\本5`}
+	invalidStrings := []string{"3abc", "45", "aaa10b", `qw\ne`, `\本5`, `qwe\`, `qwe\\45`}
 	for _, tc := range invalidStrings {
 		// tc := tc
 		t.Run(tc, func(t *testing.T) {
@@ -48,3 +52,36 @@ func TestUnpackInvalidString(t *testing.T) {
 		})
 	}
 }
+
+func TestUnpackSyntaxError(t *testing.T) {
+	tests := []struct {
+		input  string
+		offset int
+		rune   rune
+		reason SyntaxReason
+	}{
+		{input: "3abc", offset: 0, rune: '3', reason: ReasonLeadingDigit},
+		{input: "aaa10b", offset: 4, rune: '0', reason: ReasonDoubleDigit},
+		{input: `qwe\\45`, offset: 6, rune: '5', reason: ReasonDoubleDigit},
+		{input: `qw\ne`, offset: 3, rune: 'n', reason: ReasonInvalidEscape},
+		{input: `\本5`, offset: 1, rune: '本', reason: ReasonInvalidEscape},
+		{input: `qwe\`, offset: 3, rune: '\\', reason: ReasonDanglingEscape},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			result, err := Unpack(tc.input)
+			require.Empty(t, result)
+			require.ErrorIs(t, err, ErrInvalidString)
+
+			var syntaxErr *SyntaxError
+			require.ErrorAs(t, err, &syntaxErr)
+			require.Equal(t, SyntaxError{Offset: tc.offset, Rune: tc.rune, Reason: tc.reason}, *syntaxErr)
+		})
+	}
+
+	t.Run("error message", func(t *testing.T) {
+		_, err := Unpack("aaa10b")
+		require.EqualError(t, err, `invalid string: double digit '0' at rune offset 4`)
+	})
+}
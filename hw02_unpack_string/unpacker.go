@@ -0,0 +1,54 @@
+package hw02unpackstring
+
+import (
+	"bufio"
+	"io"
+)
+
+// Unpacker decodes a packed stream rune by rune with the same rules as Unpack.
+type Unpacker struct {
+	reader io.RuneReader
+	opts   Options
+}
+
+// NewUnpacker returns an Unpacker that reads the packed string from r.
+func NewUnpacker(r io.Reader) *Unpacker {
+	return NewUnpackerWithOptions(r, Options{})
+}
+
+// NewUnpackerWithOptions returns an Unpacker for the dialect described by opts.
+func NewUnpackerWithOptions(r io.Reader, opts Options) *Unpacker {
+	rr, ok := r.(io.RuneReader)
+	if !ok {
+		rr = bufio.NewReader(r)
+	}
+	return &Unpacker{reader: rr, opts: opts}
+}
+
+// WriteTo unpacks the stream into w and returns the number of bytes written.
+// The output is written incrementally, so on error w may already contain
+// the unpacked prefix of the stream.
+func (u *Unpacker) WriteTo(w io.Writer) (int64, error) {
+	if err := u.opts.validate(); err != nil {
+		return 0, err
+	}
+
+	cw := &countingWriter{w: w}
+	bw := bufio.NewWriter(cw)
+	err := newDecoder(bw, u.opts).decode(u.reader)
+	if flushErr := bw.Flush(); err == nil {
+		err = flushErr
+	}
+	return cw.n, err
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	return n, err
+}
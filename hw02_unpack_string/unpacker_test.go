@@ -0,0 +1,93 @@
+package hw02unpackstring
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnpacker(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{input: "a4bc2d5e", expected: "aaaabccddddde"},
+		{input: "abccd", expected: "abccd"},
+		{input: "", expected: ""},
+		{input: "aaa0b", expected: "aab"},
+		{input: "🙃0", expected: ""},
+		{input: "🙃3", expected: "🙃🙃🙃"},
+		{input: "本5", expected: "本本本本本"},
+		{input: "d\n5abc", expected: "d\n\n\n\n\nabc"},
+		{input: `qwe\4\5`, expected: `qwe45`},
+		{input: `qwe\45`, expected: `qwe44444`},
+		{input: `qwe\\5`, expected: `qwe\\\\\`},
+		{input: `qwe\\\3`, expected: `qwe\3`},
+		{input: `\\\\`, expected: `\\`},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			var out bytes.Buffer
+			n, err := NewUnpacker(strings.NewReader(tc.input)).WriteTo(&out)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, out.String())
+			require.Equal(t, int64(len(tc.expected)), n)
+		})
+	}
+
+	t.Run("reader without ReadRune", func(t *testing.T) {
+		var out bytes.Buffer
+		_, err := NewUnpacker(iotest.OneByteReader(strings.NewReader("п3р2🙃"))).WriteTo(&out)
+		require.NoError(t, err)
+		require.Equal(t, "пппрр🙃", out.String())
+	})
+
+	t.Run("large input", func(t *testing.T) {
+		input := strings.Repeat(`ab3\\2\5`, 100_000)
+		var out bytes.Buffer
+		_, err := NewUnpacker(strings.NewReader(input)).WriteTo(&out)
+		require.NoError(t, err)
+		require.Equal(t, strings.Repeat(`abbb\\5`, 100_000), out.String())
+	})
+}
+
+func TestUnpackerInvalidString(t *testing.T) {
+	tests := []struct {
+		input  string
+		offset int
+		rune   rune
+		reason SyntaxReason
+	}{
+		{input: "3abc", offset: 0, rune: '3', reason: ReasonLeadingDigit},
+		{input: "45", offset: 0, rune: '4', reason: ReasonLeadingDigit},
+		{input: "aaa10b", offset: 4, rune: '0', reason: ReasonDoubleDigit},
+		{input: `qw\ne`, offset: 3, rune: 'n', reason: ReasonInvalidEscape},
+		{input: `\本5`, offset: 1, rune: '本', reason: ReasonInvalidEscape},
+		{input: `абв\`, offset: 3, rune: '\\', reason: ReasonDanglingEscape},
+		{input: `a\\45`, offset: 4, rune: '5', reason: ReasonDoubleDigit},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			_, err := NewUnpacker(strings.NewReader(tc.input)).WriteTo(&bytes.Buffer{})
+			require.Truef(t, errors.Is(err, ErrInvalidString), "actual error %q", err)
+
+			var syntaxErr *SyntaxError
+			require.ErrorAs(t, err, &syntaxErr)
+			require.Equal(t, tc.offset, syntaxErr.Offset)
+			require.Equal(t, tc.rune, syntaxErr.Rune)
+			require.Equal(t, tc.reason, syntaxErr.Reason)
+		})
+	}
+
+	t.Run("read error", func(t *testing.T) {
+		readErr := errors.New("read failed")
+		_, err := NewUnpacker(iotest.ErrReader(readErr)).WriteTo(&bytes.Buffer{})
+		require.ErrorIs(t, err, readErr)
+	})
+}
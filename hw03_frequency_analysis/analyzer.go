@@ -0,0 +1,103 @@
+package hw03frequencyanalysis
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// maxWordSize - максимальная длина слова в байтах при чтении из потока.
+const maxWordSize = 1 << 20
+
+// Analyzer counts word frequencies of a stream without keeping the text in memory.
+// With WithApproximation the memory is bounded by the given number of tracked words.
+// An Analyzer is not safe for concurrent use.
+type Analyzer struct {
+	cfg     config
+	counter counter
+	window  []string // Последние слова для составления n-грамм
+}
+
+// NewAnalyzer returns an empty Analyzer configured by opts.
+func NewAnalyzer(opts ...Option) *Analyzer {
+	cfg := newConfig(opts)
+
+	var c counter = make(wordStats)
+	if cfg.capacity > 0 {
+		c = newSpaceSaving(cfg.capacity)
+	}
+	return &Analyzer{cfg: cfg, counter: c}
+}
+
+// ReadFrom reads r word by word until EOF and adds the words to the statistics.
+// It may be called several times to analyze a sequence of streams, n-grams never span two streams.
+func (a *Analyzer) ReadFrom(r io.Reader) (int64, error) {
+	cr := &countingReader{r: r}
+	err := a.scan(cr, maxWordSize)
+	return cr.n, err
+}
+
+// Top returns the n most frequent words seen so far.
+func (a *Analyzer) Top(n int) []WordCount {
+	if n <= 0 {
+		return []WordCount{}
+	}
+	return a.counter.top(n, a.cfg)
+}
+
+// scan разбивает поток на слова токенизатором из опций и добавляет их в статистику.
+func (a *Analyzer) scan(r io.Reader, maxTokenSize int) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, min(maxTokenSize, bufio.MaxScanTokenSize)), maxTokenSize)
+	scanner.Split(a.cfg.tokenizer.Split)
+
+	a.window = a.window[:0]
+	for scanner.Scan() {
+		raw := scanner.Text()
+		if word, ok := normalize(a.cfg.normalizers, raw); ok {
+			a.add(word)
+		}
+		// N-граммы не переходят через конец предложения
+		if a.cfg.sentences && endsSentence(raw) {
+			a.window = a.window[:0]
+		}
+	}
+	return scanner.Err()
+}
+
+// add учитывает слово или, в режиме n-грамм, n-грамму, которую это слово завершает.
+func (a *Analyzer) add(word string) {
+	if a.cfg.ngram <= 1 {
+		a.counter.add(word)
+		return
+	}
+
+	if len(a.window) == a.cfg.ngram {
+		copy(a.window, a.window[1:])
+		a.window = a.window[:len(a.window)-1]
+	}
+	a.window = append(a.window, word)
+	if len(a.window) == a.cfg.ngram {
+		a.counter.add(strings.Join(a.window, " "))
+	}
+}
+
+// TopNReader returns the n most frequent words read from r.
+func TopNReader(r io.Reader, n int, opts ...Option) ([]WordCount, error) {
+	analyzer := NewAnalyzer(opts...)
+	if _, err := analyzer.ReadFrom(r); err != nil {
+		return nil, err
+	}
+	return analyzer.Top(n), nil
+}
+
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (cr *countingReader) Read(p []byte) (int, error) {
+	n, err := cr.r.Read(p)
+	cr.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,91 @@
+package hw03frequencyanalysis
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalyzer(t *testing.T) {
+	t.Run("stream matches TopN", func(t *testing.T) {
+		top, err := TopNReader(iotest.HalfReader(strings.NewReader(text)), 10)
+		require.NoError(t, err)
+		require.Equal(t, TopN(text, 10), top)
+	})
+
+	t.Run("several streams", func(t *testing.T) {
+		analyzer := NewAnalyzer()
+		n, err := analyzer.ReadFrom(strings.NewReader(shortText))
+		require.NoError(t, err)
+		require.Equal(t, int64(len(shortText)), n)
+		_, err = analyzer.ReadFrom(strings.NewReader("word9 word9 word9 word9 word9"))
+		require.NoError(t, err)
+
+		expected := []WordCount{
+			{Word: "word1", Count: 12},
+			{Word: "word9", Count: 7},
+		}
+		require.Equal(t, expected, analyzer.Top(2))
+	})
+
+	t.Run("read error", func(t *testing.T) {
+		readErr := errors.New("read failed")
+		_, err := TopNReader(iotest.ErrReader(readErr), 10)
+		require.ErrorIs(t, err, readErr)
+	})
+
+	t.Run("word is too long", func(t *testing.T) {
+		_, err := TopNReader(strings.NewReader(strings.Repeat("a", maxWordSize+1)), 10)
+		require.Error(t, err)
+	})
+}
+
+func TestAnalyzerApproximation(t *testing.T) {
+	t.Run("exact when capacity is enough", func(t *testing.T) {
+		top, err := TopNReader(strings.NewReader(text), 10, WithApproximation(1000))
+		require.NoError(t, err)
+		require.Equal(t, TopN(text, 10), top)
+	})
+
+	t.Run("heavy hitters with bounded memory", func(t *testing.T) {
+		// Zipf-подобный поток: несколько частых слов и длинный хвост редких
+		rnd := rand.New(rand.NewSource(1))
+		exact := make(map[string]int)
+		builder := strings.Builder{}
+		for i := 0; i < 100_000; i++ {
+			word := fmt.Sprintf("w%d", rnd.Intn(20_000))
+			if i%3 == 0 {
+				word = fmt.Sprintf("hot%d", rnd.Intn(5))
+			}
+			exact[word]++
+			builder.WriteString(word + " ")
+		}
+
+		capacity := 200
+		analyzer := NewAnalyzer(WithApproximation(capacity))
+		_, err := analyzer.ReadFrom(strings.NewReader(builder.String()))
+		require.NoError(t, err)
+		require.LessOrEqual(t, len(analyzer.counter.(*spaceSaving).items), capacity)
+
+		top := analyzer.Top(5)
+		require.Len(t, top, 5)
+		for _, wc := range top {
+			require.True(t, strings.HasPrefix(wc.Word, "hot"), "unexpected word %q", wc.Word)
+			// Реальная частота лежит в пределах [Count-Error, Count]
+			require.LessOrEqual(t, exact[wc.Word], wc.Count)
+			require.GreaterOrEqual(t, exact[wc.Word], wc.Count-wc.Error)
+		}
+	})
+
+	t.Run("error bound after eviction", func(t *testing.T) {
+		top, err := TopNReader(strings.NewReader("a a a b c"), 10, WithApproximation(2))
+		require.NoError(t, err)
+		// "b" вытеснено словом "c", которое унаследовало его частоту как погрешность
+		require.Equal(t, []WordCount{{Word: "a", Count: 3}, {Word: "c", Count: 2, Error: 1}}, top)
+	})
+}
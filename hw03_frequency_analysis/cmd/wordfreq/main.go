@@ -0,0 +1,159 @@
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	hw03 "github.com/j85529016-prog/GoProf_01/hw03_frequency_analysis"
+)
+
+var ErrUnknownFormat = errors.New("unknown output format")
+
+// config - параметры командной строки.
+type config struct {
+	n         int
+	format    string
+	ngram     int
+	stopwords string
+	files     []string
+}
+
+func main() {
+	if err := run(os.Args[1:], os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func parseFlags(args []string) (config, error) {
+	var cfg config
+	fs := flag.NewFlagSet("wordfreq", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: wordfreq [flags] [file ...]\n"+
+			"Prints the most frequent words of the files or of stdin.\n\n")
+		fs.PrintDefaults()
+	}
+	fs.IntVar(&cfg.n, "n", 10, "number of words to print")
+	fs.StringVar(&cfg.format, "format", "text", "output format: text, json or csv")
+	fs.IntVar(&cfg.ngram, "ngram", 1, "count phrases of this many words")
+	fs.StringVar(&cfg.stopwords, "stopwords", "", "file with stop words, one per line")
+	if err := fs.Parse(args); err != nil {
+		return cfg, err
+	}
+	cfg.files = fs.Args()
+
+	switch cfg.format {
+	case "text", "json", "csv":
+	default:
+		return cfg, fmt.Errorf("%w: %q", ErrUnknownFormat, cfg.format)
+	}
+	return cfg, nil
+}
+
+func run(args []string, stdin io.Reader, stdout io.Writer) error {
+	cfg, err := parseFlags(args)
+	if err != nil {
+		return err
+	}
+
+	opts := []hw03.Option{hw03.WithNgrams(cfg.ngram)}
+	if cfg.stopwords != "" {
+		stopwords, err := readStopwords(cfg.stopwords)
+		if err != nil {
+			return err
+		}
+		// Стоп-слова проверяются после очистки и приведения к нижнему регистру
+		opts = append(opts, hw03.WithNormalizers(append(hw03.DefaultNormalizers(), stopwords)...))
+	}
+
+	analyzer := hw03.NewAnalyzer(opts...)
+	if len(cfg.files) == 0 {
+		if _, err := analyzer.ReadFrom(stdin); err != nil {
+			return fmt.Errorf("read stdin: %w", err)
+		}
+	}
+	for _, name := range cfg.files {
+		if err := readFile(analyzer, name); err != nil {
+			return err
+		}
+	}
+
+	return write(stdout, cfg.format, analyzer.Top(cfg.n))
+}
+
+func readFile(analyzer *hw03.Analyzer, name string) error {
+	file, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if _, err := analyzer.ReadFrom(file); err != nil {
+		return fmt.Errorf("read %s: %w", name, err)
+	}
+	return nil
+}
+
+func readStopwords(name string) (hw03.Stopwords, error) {
+	file, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var words []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		// Пустые строки и комментарии пропускаем
+		word := strings.ToLower(strings.TrimSpace(scanner.Text()))
+		if word == "" || strings.HasPrefix(word, "#") {
+			continue
+		}
+		words = append(words, word)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read %s: %w", name, err)
+	}
+	return hw03.NewStopwords(words...), nil
+}
+
+type jsonWordCount struct {
+	Word  string `json:"word"`
+	Count int    `json:"count"`
+}
+
+func write(w io.Writer, format string, top []hw03.WordCount) error {
+	switch format {
+	case "json":
+		result := make([]jsonWordCount, 0, len(top))
+		for _, wc := range top {
+			result = append(result, jsonWordCount{Word: wc.Word, Count: wc.Count})
+		}
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(result)
+	case "csv":
+		cw := csv.NewWriter(w)
+		_ = cw.Write([]string{"word", "count"})
+		for _, wc := range top {
+			_ = cw.Write([]string{wc.Word, strconv.Itoa(wc.Count)})
+		}
+		cw.Flush()
+		return cw.Error()
+	default:
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		for _, wc := range top {
+			fmt.Fprintf(tw, "%s\t%d\n", wc.Word, wc.Count)
+		}
+		return tw.Flush()
+	}
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+func TestRunGolden(t *testing.T) {
+	input := filepath.Join("testdata", "input.txt")
+	more := filepath.Join("testdata", "more.txt")
+	stopwords := filepath.Join("testdata", "stopwords.txt")
+
+	tests := []struct {
+		name  string
+		args  []string
+		stdin string
+	}{
+		{name: "text", args: []string{input}},
+		{name: "text_n3", args: []string{"-n", "3", input}},
+		{name: "json", args: []string{"-format=json", "-n", "5", input}},
+		{name: "csv", args: []string{"-format=csv", "-n", "5", input}},
+		{name: "ngram", args: []string{"-ngram", "2", "-n", "5", input, more}},
+		{name: "stopwords", args: []string{"-stopwords", stopwords, "-n", "5", input}},
+		{name: "several_files", args: []string{"-n", "5", input, more}},
+		{name: "stdin", args: []string{"-format=csv", "-n", "3"}, stdin: "раз два два три три три"},
+		{name: "empty", args: []string{"-format=json"}, stdin: ""},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var out bytes.Buffer
+			require.NoError(t, run(tc.args, strings.NewReader(tc.stdin), &out))
+
+			golden := filepath.Join("testdata", tc.name+".golden")
+			if *update {
+				require.NoError(t, os.WriteFile(golden, out.Bytes(), 0o600))
+			}
+			expected, err := os.ReadFile(golden)
+			require.NoError(t, err)
+			require.Equal(t, string(expected), out.String())
+		})
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	t.Run("unknown format", func(t *testing.T) {
+		err := run([]string{"-format=xml"}, strings.NewReader(""), &bytes.Buffer{})
+		require.ErrorIs(t, err, ErrUnknownFormat)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		err := run([]string{filepath.Join("testdata", "missing.txt")}, strings.NewReader(""), &bytes.Buffer{})
+		require.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("missing stopwords file", func(t *testing.T) {
+		err := run([]string{"-stopwords", "missing.txt"}, strings.NewReader(""), &bytes.Buffer{})
+		require.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
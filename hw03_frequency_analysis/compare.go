@@ -0,0 +1,131 @@
+package hw03frequencyanalysis
+
+import (
+	"math"
+	"sort"
+	"strings"
+)
+
+// Scoring defines how the change of a word frequency between two texts is measured.
+type Scoring int
+
+const (
+	// ScoreLogRatio is the binary logarithm of the ratio of relative frequencies.
+	// 0.5 is added to both counts, so words missing from one text get a finite score.
+	ScoreLogRatio Scoring = iota
+	// ScoreChiSquare is Pearson's chi-square statistic of the 2x2 contingency table
+	// "this word / other words" x "before / after".
+	ScoreChiSquare
+)
+
+// WordDiff describes how the frequency of a word changed between two texts.
+// Score is positive when the relative frequency grew and negative when it fell.
+type WordDiff struct {
+	Word        string
+	CountBefore int
+	CountAfter  int
+	FreqBefore  float64
+	FreqAfter   float64
+	Score       float64
+}
+
+// Compare returns the n words whose relative frequency changed the most between before and after,
+// ordered by the absolute value of the score. Words are cleaned by the same rules as in TopN.
+// Counting is always exact, WithApproximation is ignored.
+func Compare(before, after string, n int, scoring Scoring, opts ...Option) []WordDiff {
+	if n <= 0 {
+		return []WordDiff{}
+	}
+	cfg := newConfig(opts)
+
+	statsBefore, totalBefore := countExact(before, cfg)
+	statsAfter, totalAfter := countExact(after, cfg)
+	// Общая статистика нужна для порядка первого вхождения при равенстве оценок
+	merged := mergeStats([]wordStats{statsBefore, statsAfter})
+
+	type scoredWord struct {
+		diff WordDiff
+		stat *wordStat
+	}
+	scored := make([]scoredWord, 0, len(merged))
+	for word, stat := range merged {
+		diff := WordDiff{Word: word}
+		if s, ok := statsBefore[word]; ok {
+			diff.CountBefore = s.count
+		}
+		if s, ok := statsAfter[word]; ok {
+			diff.CountAfter = s.count
+		}
+		diff.FreqBefore = relativeFrequency(diff.CountBefore, totalBefore)
+		diff.FreqAfter = relativeFrequency(diff.CountAfter, totalAfter)
+		diff.Score = score(scoring, diff.CountBefore, totalBefore, diff.CountAfter, totalAfter)
+		scored = append(scored, scoredWord{diff, stat})
+	}
+
+	sort.Slice(scored, func(i, j int) bool {
+		scoreI, scoreJ := math.Abs(scored[i].diff.Score), math.Abs(scored[j].diff.Score)
+		// Если оценки равны - сортируем по правилу из опций
+		if scoreI == scoreJ {
+			return cfg.less(scored[i].diff.Word, scored[i].stat, scored[j].diff.Word, scored[j].stat)
+		}
+		return scoreI > scoreJ
+	})
+
+	result := make([]WordDiff, 0, min(n, len(scored)))
+	for _, v := range scored[:min(n, len(scored))] {
+		result = append(result, v.diff)
+	}
+	return result
+}
+
+// countExact точно считает слова текста и возвращает статистику и общее число слов.
+func countExact(text string, cfg config) (wordStats, int) {
+	analyzer := &Analyzer{cfg: cfg, counter: make(wordStats)}
+	_ = analyzer.scan(strings.NewReader(text), len(text)+1)
+
+	stats := analyzer.counter.(wordStats)
+	total := 0
+	for _, stat := range stats {
+		total += stat.count
+	}
+	return stats, total
+}
+
+func relativeFrequency(count, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(count) / float64(total)
+}
+
+func score(scoring Scoring, countBefore, totalBefore, countAfter, totalAfter int) float64 {
+	switch scoring {
+	case ScoreChiSquare:
+		return chiSquare(countBefore, totalBefore, countAfter, totalAfter)
+	case ScoreLogRatio:
+		return logRatio(countBefore, totalBefore, countAfter, totalAfter)
+	}
+	return logRatio(countBefore, totalBefore, countAfter, totalAfter)
+}
+
+func logRatio(countBefore, totalBefore, countAfter, totalAfter int) float64 {
+	freqBefore := (float64(countBefore) + 0.5) / (float64(totalBefore) + 1)
+	freqAfter := (float64(countAfter) + 0.5) / (float64(totalAfter) + 1)
+	return math.Log2(freqAfter / freqBefore)
+}
+
+func chiSquare(countBefore, totalBefore, countAfter, totalAfter int) float64 {
+	// Таблица сопряженности: строки - слово и остальные слова, столбцы - до и после
+	a, b := float64(countBefore), float64(countAfter)
+	c, d := float64(totalBefore-countBefore), float64(totalAfter-countAfter)
+	denominator := (a + b) * (c + d) * (a + c) * (b + d)
+	if denominator == 0 {
+		return 0
+	}
+	chi := (a + b + c + d) * (a*d - b*c) * (a*d - b*c) / denominator
+	// Знак показывает направление изменения частоты
+	if b*(a+c) < a*(b+d) {
+		return -chi
+	}
+	return chi
+}
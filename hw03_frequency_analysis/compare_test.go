@@ -0,0 +1,77 @@
+package hw03frequencyanalysis
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	logBefore = strings.Repeat("INFO request served. ", 50) + strings.Repeat("WARN slow query. ", 10)
+	logAfter  = strings.Repeat("INFO request served. ", 50) + strings.Repeat("WARN slow query. ", 2) +
+		strings.Repeat("ERROR connection refused! ", 8)
+)
+
+func TestCompare(t *testing.T) {
+	for name, scoring := range map[string]Scoring{"log ratio": ScoreLogRatio, "chi square": ScoreChiSquare} {
+		t.Run("new error message on top/"+name, func(t *testing.T) {
+			diff := Compare(logBefore, logAfter, 10, scoring)
+			require.Len(t, diff, 9)
+
+			words := make([]string, 0, 3)
+			for _, d := range diff[:3] {
+				words = append(words, d.Word)
+			}
+			require.ElementsMatch(t, []string{"error", "connection", "refused"}, words)
+			for _, d := range diff[:3] {
+				require.Equal(t, 0, d.CountBefore)
+				require.Equal(t, 8, d.CountAfter)
+				require.Positive(t, d.Score)
+			}
+
+			// Частота предупреждений упала
+			for _, d := range diff[3:6] {
+				require.Contains(t, []string{"warn", "slow", "query"}, d.Word)
+				require.Negative(t, d.Score)
+			}
+		})
+	}
+
+	t.Run("log ratio", func(t *testing.T) {
+		diff := Compare("a a b b", "a a a b", 10, ScoreLogRatio)
+		require.Equal(t, "b", diff[0].Word)
+		require.InDelta(t, math.Log2(1.5/2.5), diff[0].Score, 1e-9)
+		require.Equal(t, "a", diff[1].Word)
+		require.Equal(t, 0.5, diff[1].FreqBefore)
+		require.Equal(t, 0.75, diff[1].FreqAfter)
+		require.InDelta(t, math.Log2(3.5/2.5), diff[1].Score, 1e-9)
+	})
+
+	t.Run("chi square", func(t *testing.T) {
+		diff := Compare("x x x y", "x y y y", 10, ScoreChiSquare)
+		// Таблица 3/1 против 1/3: chi2 = 8 * (9 - 1)^2 / (4 * 4 * 4 * 4)
+		require.InDelta(t, 2.0, math.Abs(diff[0].Score), 1e-9)
+		require.InDelta(t, 2.0, math.Abs(diff[1].Score), 1e-9)
+		// При равных оценках - лексикографический порядок
+		require.Equal(t, "x", diff[0].Word)
+		require.Negative(t, diff[0].Score)
+		require.Equal(t, "y", diff[1].Word)
+		require.Positive(t, diff[1].Score)
+	})
+
+	t.Run("same normalization as Top10", func(t *testing.T) {
+		diff := Compare("Нога, нога", "нога! - 'НОГА'", 10, ScoreLogRatio)
+		require.Equal(t, []WordDiff{{
+			Word: "нога", CountBefore: 2, CountAfter: 2, FreqBefore: 1, FreqAfter: 1, Score: 0,
+		}}, diff)
+	})
+
+	t.Run("empty texts", func(t *testing.T) {
+		require.Empty(t, Compare("", "", 10, ScoreChiSquare))
+		diff := Compare("", "new", 10, ScoreChiSquare)
+		require.Equal(t, []WordDiff{{Word: "new", CountAfter: 1, FreqAfter: 1}}, diff)
+		require.Empty(t, Compare("a", "b", 0, ScoreLogRatio))
+	})
+}
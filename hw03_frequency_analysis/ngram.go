@@ -0,0 +1,20 @@
+package hw03frequencyanalysis
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// TopNgrams returns the n most frequent phrases of size consecutive words in text.
+// Words are cleaned by the same rules as in TopN, phrases are joined with a single space.
+func TopNgrams(text string, n, size int, opts ...Option) []WordCount {
+	return TopN(text, n, append(opts[:len(opts):len(opts)], WithNgrams(size))...)
+}
+
+// endsSentence сообщает, заканчивается ли сырое слово знаком конца предложения,
+// возможно, с закрывающими кавычками или скобками после него.
+func endsSentence(raw string) bool {
+	raw = strings.TrimRight(raw, `"')]}»”’`)
+	r, _ := utf8.DecodeLastRuneInString(raw)
+	return r == '.' || r == '!' || r == '?' || r == '…'
+}
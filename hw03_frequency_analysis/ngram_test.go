@@ -0,0 +1,67 @@
+package hw03frequencyanalysis
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTopNgrams(t *testing.T) {
+	t.Run("bigrams", func(t *testing.T) {
+		top := TopNgrams(text, 3, 2)
+		require.Len(t, top, 3)
+		require.Equal(t, WordCount{Word: "кристофер робин", Count: 4}, top[0])
+		require.Equal(t, WordCount{Word: "а если", Count: 2}, top[1])
+	})
+
+	t.Run("same cleaning as Top10", func(t *testing.T) {
+		input := "Нога, НОГА - нога! 'нога'"
+		require.Equal(t, []WordCount{{Word: "нога нога", Count: 3}}, TopNgrams(input, 10, 2))
+	})
+
+	t.Run("trigrams", func(t *testing.T) {
+		input := strings.Repeat("раз два три ", 3)
+		expected := []WordCount{
+			{Word: "раз два три", Count: 3},
+			{Word: "два три раз", Count: 2},
+			{Word: "три раз два", Count: 2},
+		}
+		require.Equal(t, expected, TopNgrams(input, 10, 3))
+	})
+
+	t.Run("size one is TopN", func(t *testing.T) {
+		require.Equal(t, TopN(text, 10), TopNgrams(text, 10, 1))
+	})
+
+	t.Run("text shorter than n-gram", func(t *testing.T) {
+		require.Empty(t, TopNgrams("раз два", 10, 3))
+	})
+
+	t.Run("sentence boundaries", func(t *testing.T) {
+		input := `Иди домой. Домой иди! "Иди домой?" Иди… (домой иди.) домой`
+		require.Equal(t, []WordCount{
+			{Word: "иди домой", Count: 4},
+			{Word: "домой иди", Count: 3},
+			{Word: "домой домой", Count: 1},
+			{Word: "иди иди", Count: 1},
+		}, TopNgrams(input, 10, 2))
+		require.Equal(t, []WordCount{
+			{Word: "домой иди", Count: 2},
+			{Word: "иди домой", Count: 2},
+		}, TopNgrams(input, 10, 2, WithSentenceBoundaries()))
+	})
+
+	t.Run("streams do not join", func(t *testing.T) {
+		analyzer := NewAnalyzer(WithNgrams(2))
+		_, err := analyzer.ReadFrom(strings.NewReader("раз два"))
+		require.NoError(t, err)
+		_, err = analyzer.ReadFrom(strings.NewReader("три четыре"))
+		require.NoError(t, err)
+		require.Equal(t, []WordCount{{Word: "раз два", Count: 1}, {Word: "три четыре", Count: 1}}, analyzer.Top(10))
+	})
+
+	t.Run("parallel falls back to sequential", func(t *testing.T) {
+		require.Equal(t, TopNgrams(text, 10, 2), TopNParallel(text, 10, 4, WithNgrams(2)))
+	})
+}
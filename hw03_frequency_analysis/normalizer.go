@@ -0,0 +1,95 @@
+package hw03frequencyanalysis
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Normalizer transforms a raw word before it is counted. ok == false drops the word.
+type Normalizer interface {
+	Normalize(word string) (normalized string, ok bool)
+}
+
+// NormalizerFunc adapts a function to the Normalizer interface.
+type NormalizerFunc func(word string) (string, bool)
+
+func (f NormalizerFunc) Normalize(word string) (string, bool) {
+	return f(word)
+}
+
+// Шаблон для очистки слов от знаков препинания и пробелов по краям слова.
+var cleanPattern = regexp.MustCompile(`^[\p{P}\s]+|[\p{P}\s]+$`)
+
+var (
+	// TrimPunctuation removes punctuation and white space around the word.
+	TrimPunctuation Normalizer = NormalizerFunc(func(word string) (string, bool) {
+		return cleanPattern.ReplaceAllString(word, ""), true
+	})
+	// Lowercase converts the word to lower case.
+	Lowercase Normalizer = NormalizerFunc(func(word string) (string, bool) {
+		return strings.ToLower(word), true
+	})
+	// SkipDashes drops empty words and the lone dash, which is not a word.
+	SkipDashes Normalizer = NormalizerFunc(func(word string) (string, bool) {
+		return word, word != "" && word != "-"
+	})
+	// CaseFold folds the word case for caseless matching: unlike Lowercase it also
+	// unifies variants such as final sigma or long s, and expands "ß" to "ss".
+	CaseFold Normalizer = NormalizerFunc(func(word string) (string, bool) {
+		return caseFoldReplacer.Replace(strings.ToLower(strings.ToUpper(word))), true
+	})
+)
+
+var caseFoldReplacer = strings.NewReplacer("ß", "ss", "ẞ", "ss")
+
+// DefaultNormalizers returns the chain used when no normalizers are configured:
+// trim punctuation, convert to lower case and skip empty words and dashes.
+func DefaultNormalizers() []Normalizer {
+	return []Normalizer{TrimPunctuation, Lowercase, SkipDashes}
+}
+
+// normalize прогоняет слово через цепочку нормализаторов; false - слово надо пропустить.
+func normalize(chain []Normalizer, word string) (string, bool) {
+	for _, n := range chain {
+		var ok bool
+		if word, ok = n.Normalize(word); !ok {
+			return "", false
+		}
+	}
+	return word, true
+}
+
+// Stopwords drops words from a stop list. Words are compared as is, so put it after
+// Lowercase or CaseFold in the chain.
+type Stopwords map[string]struct{}
+
+// NewStopwords returns a Stopwords normalizer for the given words.
+func NewStopwords(words ...string) Stopwords {
+	s := make(Stopwords, len(words))
+	for _, w := range words {
+		s[w] = struct{}{}
+	}
+	return s
+}
+
+func (s Stopwords) Normalize(word string) (string, bool) {
+	_, stop := s[word]
+	return word, !stop
+}
+
+// EnglishStopwords is a short list of the most frequent English function words.
+var EnglishStopwords = []string{
+	"a", "an", "and", "are", "as", "at", "be", "but", "by", "for", "from", "had", "has", "have",
+	"he", "her", "his", "i", "if", "in", "into", "is", "it", "its", "not", "of", "on", "or",
+	"she", "so", "that", "the", "their", "them", "then", "there", "these", "they", "this",
+	"to", "was", "we", "were", "what", "when", "which", "who", "will", "with", "you",
+}
+
+// RussianStopwords is a short list of the most frequent Russian function words.
+var RussianStopwords = []string{
+	"а", "без", "бы", "был", "была", "были", "было", "в", "вот", "вы", "да", "для", "до",
+	"его", "ее", "её", "если", "есть", "еще", "ещё", "же", "за", "и", "из", "или", "им", "их",
+	"к", "как", "когда", "ли", "мне", "мы", "на", "не", "нет", "ни", "но", "о", "об", "он",
+	"она", "они", "оно", "от", "по", "при", "с", "со", "так", "также", "то", "ты", "у", "уже",
+	"что", "чтобы", "это", "я",
+}
@@ -0,0 +1,90 @@
+package hw03frequencyanalysis
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizers(t *testing.T) {
+	tests := []struct {
+		name     string
+		chain    []Normalizer
+		input    string
+		expected string
+		ok       bool
+	}{
+		{name: "default", chain: DefaultNormalizers(), input: `"Нога!"`, expected: "нога", ok: true},
+		{name: "default keeps inner dash", chain: DefaultNormalizers(), input: "Какой-то,", expected: "какой-то", ok: true},
+		{name: "default skips dash", chain: DefaultNormalizers(), input: "-", ok: false},
+		{name: "default skips punctuation", chain: DefaultNormalizers(), input: "...", ok: false},
+		{name: "empty chain", chain: nil, input: "Нога!", expected: "Нога!", ok: true},
+		{name: "case fold sharp s", chain: []Normalizer{CaseFold}, input: "STRASSE", expected: "strasse", ok: true},
+		{name: "case fold eszett", chain: []Normalizer{CaseFold}, input: "Straße", expected: "strasse", ok: true},
+		{name: "case fold final sigma", chain: []Normalizer{CaseFold}, input: "ΟΔΟΣ", expected: "οδοσ", ok: true},
+		{name: "case fold small final sigma", chain: []Normalizer{CaseFold}, input: "οδος", expected: "οδοσ", ok: true},
+		{
+			name:  "stopwords after lowercase",
+			chain: []Normalizer{Lowercase, NewStopwords(EnglishStopwords...)},
+			input: "The",
+			ok:    false,
+		},
+		{
+			name:     "stopwords are case sensitive",
+			chain:    []Normalizer{NewStopwords(EnglishStopwords...)},
+			input:    "The",
+			expected: "The",
+			ok:       true,
+		},
+		{
+			name:     "stemming",
+			chain:    append(DefaultNormalizers(), RussianStemmer),
+			input:    "Лестницы,",
+			expected: "лестниц",
+			ok:       true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, ok := normalize(tc.chain, tc.input)
+			require.Equal(t, tc.ok, ok)
+			require.Equal(t, tc.expected, result)
+		})
+	}
+}
+
+func TestTopNWithNormalizers(t *testing.T) {
+	t.Run("default chain is explicit", func(t *testing.T) {
+		require.Equal(t, TopN(text, 10), TopN(text, 10, WithNormalizers(DefaultNormalizers()...)))
+	})
+
+	t.Run("empty chain counts raw words", func(t *testing.T) {
+		expected := []WordCount{
+			{Word: "он", Count: 8},
+			{Word: "а", Count: 6},
+			{Word: "и", Count: 6},
+			{Word: "ты", Count: 5},
+			{Word: "что", Count: 5},
+		}
+		require.Equal(t, expected, TopN(text, 5, WithNormalizers()))
+	})
+
+	t.Run("stopwords and stemming", func(t *testing.T) {
+		chain := append(DefaultNormalizers(), NewStopwords(RussianStopwords...), RussianStemmer)
+		top := TopN(text, 3, WithNormalizers(chain...))
+		require.Equal(t, []WordCount{
+			{Word: "кристофер", Count: 6},
+			{Word: "робин", Count: 6},
+			{Word: "знает", Count: 4},
+		}, top)
+	})
+
+	t.Run("english stopwords", func(t *testing.T) {
+		input := strings.Repeat("the cat and the dogs ", 3) + "dog"
+		chain := append(DefaultNormalizers(), NewStopwords(EnglishStopwords...), EnglishStemmer)
+		top := TopN(input, 10, WithNormalizers(chain...))
+		require.Equal(t, []WordCount{{Word: "dog", Count: 4}, {Word: "cat", Count: 3}}, top)
+	})
+}
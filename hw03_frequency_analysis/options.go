@@ -0,0 +1,112 @@
+package hw03frequencyanalysis
+
+// TieBreak defines the order of words that have equal counts.
+type TieBreak int
+
+const (
+	// TieBreakLexicographic orders words by byte-wise string comparison.
+	TieBreakLexicographic TieBreak = iota
+	// TieBreakFirstOccurrence orders words by their first appearance in the text.
+	TieBreakFirstOccurrence
+	// TieBreakCollation orders words with the collator set by WithCollator.
+	TieBreakCollation
+)
+
+// Option configures TopN and the other analysis functions.
+type Option func(*config)
+
+type config struct {
+	tieBreak    TieBreak
+	collator    func(a, b string) int
+	capacity    int
+	tokenizer   Tokenizer
+	normalizers []Normalizer
+	ngram       int
+	sentences   bool
+}
+
+func newConfig(opts []Option) config {
+	cfg := config{
+		tieBreak:    TieBreakLexicographic,
+		tokenizer:   FieldsTokenizer,
+		normalizers: DefaultNormalizers(),
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// WithTieBreak sets the order of words with equal counts.
+func WithTieBreak(tb TieBreak) Option {
+	return func(c *config) {
+		c.tieBreak = tb
+	}
+}
+
+// WithCollator orders words with equal counts by a locale collation, for example
+// collate.New(language.Russian).CompareString from golang.org/x/text.
+func WithCollator(compare func(a, b string) int) Option {
+	return func(c *config) {
+		c.tieBreak = TieBreakCollation
+		c.collator = compare
+	}
+}
+
+// WithApproximation switches counting to the Space-Saving algorithm that tracks at most
+// capacity words. Memory stays bounded on any input, every returned count may overestimate
+// the real one by at most WordCount.Error. Non-positive capacity means exact counting.
+func WithApproximation(capacity int) Option {
+	return func(c *config) {
+		c.capacity = capacity
+	}
+}
+
+// WithTokenizer sets how the text is split into words, FieldsTokenizer by default.
+func WithTokenizer(t Tokenizer) Option {
+	return func(c *config) {
+		c.tokenizer = t
+	}
+}
+
+// WithNormalizers replaces the default normalizer chain. Normalizers run in the given order,
+// the first one that drops the word stops the chain.
+func WithNormalizers(chain ...Normalizer) Option {
+	return func(c *config) {
+		c.normalizers = chain
+	}
+}
+
+// WithNgrams counts phrases of size consecutive words instead of single words.
+// Words of a phrase are joined with a single space. Size below 2 means single words.
+func WithNgrams(size int) Option {
+	return func(c *config) {
+		c.ngram = size
+	}
+}
+
+// WithSentenceBoundaries skips n-grams that cross sentence-ending punctuation (".", "!", "?", "…").
+// The punctuation is looked up in raw tokens, so the tokenizer has to keep it, as FieldsTokenizer does.
+func WithSentenceBoundaries() Option {
+	return func(c *config) {
+		c.sentences = true
+	}
+}
+
+func (c config) less(wordA string, statA *wordStat, wordB string, statB *wordStat) bool {
+	switch c.tieBreak {
+	case TieBreakFirstOccurrence:
+		return statA.first < statB.first
+	case TieBreakCollation:
+		// Без коллатора сравниваем лексикографически, а равные по коллации слова - тоже
+		if c.collator != nil {
+			if cmp := c.collator(wordA, wordB); cmp != 0 {
+				return cmp < 0
+			}
+		}
+		return wordA < wordB
+	case TieBreakLexicographic:
+		return wordA < wordB
+	}
+	return wordA < wordB
+}
@@ -0,0 +1,86 @@
+package hw03frequencyanalysis
+
+import (
+	"runtime"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// TopNParallel is TopN for large texts: the text is split on white space into chunks,
+// each chunk is counted on its own goroutine and the partial counts are merged.
+// The result equals the result of TopN, including the order of ties.
+// workers <= 0 means runtime.GOMAXPROCS(0). Counting is always exact, WithApproximation is ignored.
+// The tokenizer must not produce words that contain white space.
+// N-grams may cross chunk borders, so with WithNgrams the text is counted by TopN.
+func TopNParallel(text string, n, workers int, opts ...Option) []WordCount {
+	if text == "" || n <= 0 {
+		return []WordCount{}
+	}
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	cfg := newConfig(opts)
+	if cfg.ngram > 1 {
+		return TopN(text, n, opts...)
+	}
+
+	// Считаем каждый кусок в своей горутине в приватную мапу
+	chunks := splitChunks(text, workers)
+	partial := make([]wordStats, len(chunks))
+	var wg sync.WaitGroup
+	wg.Add(len(chunks))
+	for i, chunk := range chunks {
+		go func() {
+			defer wg.Done()
+			analyzer := &Analyzer{cfg: cfg, counter: make(wordStats)}
+			_ = analyzer.scan(strings.NewReader(chunk), len(chunk)+1)
+			partial[i] = analyzer.counter.(wordStats)
+		}()
+	}
+	wg.Wait()
+
+	return mergeStats(partial).top(n, cfg)
+}
+
+// splitChunks делит текст не более чем на count кусков примерно равной длины по пробельным символам.
+func splitChunks(text string, count int) []string {
+	size := len(text)/count + 1
+	chunks := make([]string, 0, count)
+	for len(text) > size {
+		// Ищем ближайший пробельный символ после границы куска, начиная с начала руны
+		cut := size
+		for cut < len(text) && !utf8.RuneStart(text[cut]) {
+			cut++
+		}
+		space := strings.IndexFunc(text[cut:], unicode.IsSpace)
+		if space < 0 {
+			break
+		}
+		cut += space
+		chunks = append(chunks, text[:cut])
+		text = text[cut:]
+	}
+	return append(chunks, text)
+}
+
+// mergeStats объединяет статистику кусков. Куски и их слова обходятся в порядке первого вхождения,
+// поэтому порядковые номера первых вхождений совпадают с последовательным подсчетом.
+func mergeStats(parts []wordStats) wordStats {
+	merged := make(wordStats)
+	for _, part := range parts {
+		words := make([]string, len(part))
+		for word, stat := range part {
+			words[stat.first] = word
+		}
+		for _, word := range words {
+			if stat, ok := merged[word]; ok {
+				stat.count += part[word].count
+				continue
+			}
+			merged[word] = &wordStat{count: part[word].count, first: len(merged)}
+		}
+	}
+	return merged
+}
@@ -0,0 +1,98 @@
+package hw03frequencyanalysis
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTopNParallel(t *testing.T) {
+	t.Run("empty text", func(t *testing.T) {
+		require.Empty(t, TopNParallel("", 10, 4))
+		require.Empty(t, TopNParallel(text, 0, 4))
+	})
+
+	t.Run("matches sequential result", func(t *testing.T) {
+		texts := map[string]string{
+			"text":       text,
+			"short text": shortText,
+			"one word":   "слово",
+			"no spaces":  strings.Repeat("ab", 1000),
+		}
+		tieBreaks := []TieBreak{TieBreakLexicographic, TieBreakFirstOccurrence}
+
+		for name, input := range texts {
+			for _, tb := range tieBreaks {
+				expected := TopN(input, 50, WithTieBreak(tb))
+				for _, workers := range []int{-1, 1, 3, 64} {
+					t.Run(fmt.Sprintf("%s/tie %d/workers %d", name, tb, workers), func(t *testing.T) {
+						require.Equal(t, expected, TopNParallel(input, 50, workers, WithTieBreak(tb)))
+					})
+				}
+			}
+		}
+
+		// Большой текст долго считается под -race, поэтому проверяем его один раз на каждое правило
+		random := randomText(rand.New(rand.NewSource(1)), 20_000)
+		for _, tb := range tieBreaks {
+			t.Run(fmt.Sprintf("random/tie %d", tb), func(t *testing.T) {
+				require.Equal(t, TopN(random, 50, WithTieBreak(tb)), TopNParallel(random, 50, 7, WithTieBreak(tb)))
+			})
+		}
+	})
+
+	t.Run("word tokenizer", func(t *testing.T) {
+		opts := []Option{WithTokenizer(WordTokenizer), WithTieBreak(TieBreakFirstOccurrence)}
+		require.Equal(t, TopN(text, 20, opts...), TopNParallel(text, 20, 5, opts...))
+	})
+}
+
+func TestSplitChunks(t *testing.T) {
+	input := "раз два  три\tчетыре\nпять"
+	for count := 1; count <= 10; count++ {
+		chunks := splitChunks(input, count)
+		require.LessOrEqual(t, len(chunks), count)
+		require.Equal(t, input, strings.Join(chunks, ""))
+		// Куски не разрезают слова
+		var words []string
+		for _, chunk := range chunks {
+			words = append(words, strings.Fields(chunk)...)
+		}
+		require.Equal(t, strings.Fields(input), words)
+	}
+}
+
+// randomText - текст из слов с распределением, близким к закону Ципфа.
+func randomText(rnd *rand.Rand, words int) string {
+	zipf := rand.NewZipf(rnd, 1.1, 1, 5000)
+	builder := strings.Builder{}
+	separators := []string{" ", "  ", "\n", "\t", ", ", ". ", " - "}
+	for i := 0; i < words; i++ {
+		fmt.Fprintf(&builder, "Слово%d", zipf.Uint64())
+		builder.WriteString(separators[rnd.Intn(len(separators))])
+	}
+	return builder.String()
+}
+
+func BenchmarkTopN(b *testing.B) {
+	corpus := randomText(rand.New(rand.NewSource(1)), 1_000_000)
+
+	b.Run("Top10", func(b *testing.B) {
+		b.SetBytes(int64(len(corpus)))
+		for i := 0; i < b.N; i++ {
+			Top10(corpus)
+		}
+	})
+
+	for _, workers := range []int{1, 2, 4, 8} {
+		b.Run(fmt.Sprintf("TopNParallel/workers=%d", workers), func(b *testing.B) {
+			b.SetBytes(int64(len(corpus)))
+			for i := 0; i < b.N; i++ {
+				TopNParallel(corpus, 10, workers)
+			}
+		})
+	}
+}
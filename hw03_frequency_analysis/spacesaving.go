@@ -0,0 +1,87 @@
+package hw03frequencyanalysis
+
+import "container/heap"
+
+// spaceSaving - приближенный подсчет частоты алгоритмом Space-Saving (Metwally et al.).
+// Хранит не больше capacity слов; при вытеснении новое слово наследует частоту вытесненного,
+// которая и становится погрешностью. Любое слово с частотой больше N/capacity гарантированно
+// присутствует в статистике.
+type spaceSaving struct {
+	capacity int
+	items    map[string]*ssItem
+	heap     ssHeap
+	inserted int // Счетчик вставок для порядка первого вхождения
+}
+
+type ssItem struct {
+	word  string
+	stat  wordStat
+	index int
+}
+
+func newSpaceSaving(capacity int) *spaceSaving {
+	return &spaceSaving{
+		capacity: capacity,
+		items:    make(map[string]*ssItem, capacity),
+		heap:     make(ssHeap, 0, capacity),
+	}
+}
+
+func (s *spaceSaving) add(word string) {
+	// Слово уже отслеживается - увеличиваем частоту
+	if item, ok := s.items[word]; ok {
+		item.stat.count++
+		heap.Fix(&s.heap, item.index)
+		return
+	}
+
+	s.inserted++
+	// Есть свободное место - добавляем слово с точной частотой
+	if len(s.heap) < s.capacity {
+		item := &ssItem{word: word, stat: wordStat{count: 1, first: s.inserted}}
+		s.items[word] = item
+		heap.Push(&s.heap, item)
+		return
+	}
+
+	// Вытесняем слово с минимальной частотой
+	item := s.heap[0]
+	delete(s.items, item.word)
+	item.word = word
+	item.stat = wordStat{count: item.stat.count + 1, first: s.inserted, err: item.stat.count}
+	s.items[word] = item
+	heap.Fix(&s.heap, 0)
+}
+
+func (s *spaceSaving) top(n int, cfg config) []WordCount {
+	slWordFreq := make([]wordFreq, 0, len(s.heap))
+	for _, item := range s.heap {
+		slWordFreq = append(slWordFreq, wordFreq{item.word, &item.stat})
+	}
+	return rank(slWordFreq, n, cfg)
+}
+
+// ssHeap - min-куча слов по частоте для heap.Interface.
+type ssHeap []*ssItem
+
+func (h ssHeap) Len() int           { return len(h) }
+func (h ssHeap) Less(i, j int) bool { return h[i].stat.count < h[j].stat.count }
+
+func (h ssHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *ssHeap) Push(x any) {
+	item := x.(*ssItem)
+	item.index = len(*h)
+	*h = append(*h, item)
+}
+
+func (h *ssHeap) Pop() any {
+	old := *h
+	item := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return item
+}
@@ -0,0 +1,366 @@
+package hw03frequencyanalysis
+
+import (
+	"sort"
+	"strings"
+)
+
+var (
+	// EnglishStemmer reduces lower-case English words to their stems with the Porter algorithm.
+	// Words with characters other than a-z are left as is.
+	EnglishStemmer Normalizer = NormalizerFunc(func(word string) (string, bool) {
+		return porterStem(word), true
+	})
+	// RussianStemmer reduces lower-case Russian words to their stems with the Snowball algorithm.
+	RussianStemmer Normalizer = NormalizerFunc(func(word string) (string, bool) {
+		return russianStem(word), true
+	})
+)
+
+// porter - состояние алгоритма Портера: b[:k+1] - текущее слово, j - граница основы.
+type porter struct {
+	b    []byte
+	k, j int
+}
+
+func porterStem(word string) string {
+	if len(word) <= 2 || strings.IndexFunc(word, func(r rune) bool { return r < 'a' || r > 'z' }) >= 0 {
+		return word
+	}
+	p := &porter{b: []byte(word), k: len(word) - 1}
+	p.step1ab()
+	if p.k > 0 {
+		p.step1c()
+		p.step2()
+		p.step3()
+		p.step4()
+		p.step5()
+	}
+	return string(p.b[:p.k+1])
+}
+
+// cons сообщает, является ли b[i] согласной.
+func (p *porter) cons(i int) bool {
+	switch p.b[i] {
+	case 'a', 'e', 'i', 'o', 'u':
+		return false
+	case 'y':
+		return i == 0 || !p.cons(i-1)
+	}
+	return true
+}
+
+// m - число последовательностей VC в основе b[:j+1].
+func (p *porter) m() int {
+	n, i := 0, 0
+	for ; i <= p.j && p.cons(i); i++ {
+	}
+	for i <= p.j {
+		for ; i <= p.j && !p.cons(i); i++ {
+		}
+		if i > p.j {
+			break
+		}
+		n++
+		for ; i <= p.j && p.cons(i); i++ {
+		}
+	}
+	return n
+}
+
+func (p *porter) vowelInStem() bool {
+	for i := 0; i <= p.j; i++ {
+		if !p.cons(i) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *porter) doublec(j int) bool {
+	return j >= 1 && p.b[j] == p.b[j-1] && p.cons(j)
+}
+
+// cvc - b[i-2:i+1] имеет вид согласная-гласная-согласная, и последняя не w, x или y.
+func (p *porter) cvc(i int) bool {
+	if i < 2 || !p.cons(i) || p.cons(i-1) || !p.cons(i-2) {
+		return false
+	}
+	switch p.b[i] {
+	case 'w', 'x', 'y':
+		return false
+	}
+	return true
+}
+
+func (p *porter) ends(s string) bool {
+	if len(s) > p.k+1 || string(p.b[p.k+1-len(s):p.k+1]) != s {
+		return false
+	}
+	p.j = p.k - len(s)
+	return true
+}
+
+func (p *porter) setTo(s string) {
+	p.b = append(p.b[:p.j+1], s...)
+	p.k = p.j + len(s)
+}
+
+func (p *porter) replaceIfMeasured(s string) {
+	if p.m() > 0 {
+		p.setTo(s)
+	}
+}
+
+func (p *porter) step1ab() {
+	if p.b[p.k] == 's' {
+		switch {
+		case p.ends("sses"):
+			p.k -= 2
+		case p.ends("ies"):
+			p.setTo("i")
+		case p.b[p.k-1] != 's':
+			p.k--
+		}
+	}
+
+	if p.ends("eed") {
+		if p.m() > 0 {
+			p.k--
+		}
+		return
+	}
+	if !(p.ends("ed") || p.ends("ing")) || !p.vowelInStem() {
+		return
+	}
+
+	p.k = p.j
+	switch {
+	case p.ends("at"):
+		p.setTo("ate")
+	case p.ends("bl"):
+		p.setTo("ble")
+	case p.ends("iz"):
+		p.setTo("ize")
+	case p.doublec(p.k):
+		switch p.b[p.k] {
+		case 'l', 's', 'z':
+		default:
+			p.k--
+		}
+	default:
+		p.j = p.k
+		if p.m() == 1 && p.cvc(p.k) {
+			p.setTo("e")
+		}
+	}
+}
+
+func (p *porter) step1c() {
+	if p.ends("y") && p.vowelInStem() {
+		p.b[p.k] = 'i'
+	}
+}
+
+// porterStep2 и porterStep3 - замены суффиксов для основ с m() > 0.
+var (
+	porterStep2 = [][2]string{
+		{"ational", "ate"}, {"tional", "tion"}, {"enci", "ence"}, {"anci", "ance"}, {"izer", "ize"},
+		{"bli", "ble"}, {"alli", "al"}, {"entli", "ent"}, {"eli", "e"}, {"ousli", "ous"},
+		{"ization", "ize"}, {"ation", "ate"}, {"ator", "ate"}, {"alism", "al"}, {"iveness", "ive"},
+		{"fulness", "ful"}, {"ousness", "ous"}, {"aliti", "al"}, {"iviti", "ive"}, {"biliti", "ble"},
+		{"logi", "log"},
+	}
+	porterStep3 = [][2]string{
+		{"icate", "ic"}, {"ative", ""}, {"alize", "al"}, {"iciti", "ic"}, {"ical", "ic"},
+		{"ful", ""}, {"ness", ""},
+	}
+	porterStep4 = []string{
+		"al", "ance", "ence", "er", "ic", "able", "ible", "ant", "ement", "ment", "ent",
+		"ion", "ou", "ism", "ate", "iti", "ous", "ive", "ize",
+	}
+)
+
+func (p *porter) replaceSuffix(rules [][2]string) {
+	for _, rule := range rules {
+		if p.ends(rule[0]) {
+			p.replaceIfMeasured(rule[1])
+			return
+		}
+	}
+}
+
+func (p *porter) step2() {
+	p.replaceSuffix(porterStep2)
+}
+
+func (p *porter) step3() {
+	p.replaceSuffix(porterStep3)
+}
+
+func (p *porter) step4() {
+	for _, suffix := range porterStep4 {
+		if !p.ends(suffix) {
+			continue
+		}
+		// Суффикс -ion удаляется только после s или t
+		if suffix == "ion" && (p.j < 0 || (p.b[p.j] != 's' && p.b[p.j] != 't')) {
+			return
+		}
+		if p.m() > 1 {
+			p.k = p.j
+		}
+		return
+	}
+}
+
+func (p *porter) step5() {
+	p.j = p.k
+	if p.b[p.k] == 'e' {
+		a := p.m()
+		if a > 1 || (a == 1 && !p.cvc(p.k-1)) {
+			p.k--
+		}
+	}
+	if p.b[p.k] == 'l' && p.doublec(p.k) && p.m() > 1 {
+		p.k--
+	}
+}
+
+// Окончания русского стеммера Snowball. Окончания первой группы должны следовать за "а" или "я".
+var (
+	ruPerfectiveGerund1 = []string{"в", "вши", "вшись"}
+	ruPerfectiveGerund2 = []string{"ив", "ивши", "ившись", "ыв", "ывши", "ывшись"}
+	ruAdjective         = []string{
+		"ее", "ие", "ые", "ое", "ими", "ыми", "ей", "ий", "ый", "ой", "ем", "им", "ым", "ом",
+		"его", "ого", "ему", "ому", "их", "ых", "ую", "юю", "ая", "яя", "ою", "ею",
+	}
+	ruParticiple1 = []string{"ем", "нн", "вш", "ющ", "щ"}
+	ruParticiple2 = []string{"ивш", "ывш", "ующ"}
+	ruReflexive   = []string{"ся", "сь"}
+	ruVerb1       = []string{
+		"ла", "на", "ете", "йте", "ли", "й", "л", "ем", "н", "ло", "но", "ет", "ют", "ны", "ть", "ешь", "нно",
+	}
+	ruVerb2 = []string{
+		"ила", "ыла", "ена", "ейте", "уйте", "ите", "или", "ыли", "ей", "уй", "ил", "ыл", "им", "ым",
+		"ен", "ило", "ыло", "ено", "ят", "ует", "уют", "ит", "ыт", "ены", "ить", "ыть", "ишь", "ую", "ю",
+	}
+	ruNoun = []string{
+		"а", "ев", "ов", "ие", "ье", "е", "иями", "ями", "ами", "еи", "ии", "и", "ией", "ей", "ой",
+		"ий", "й", "иям", "ям", "ием", "ем", "ам", "ом", "о", "у", "ах", "иях", "ях", "ы", "ь",
+		"ию", "ью", "ю", "ия", "ья", "я",
+	}
+	ruSuperlative  = []string{"ейш", "ейше"}
+	ruDerivational = []string{"ост", "ость"}
+)
+
+// ruEnding - окончание; afterAYa - окончание допустимо только после "а" или "я".
+type ruEnding struct {
+	suffix   []rune
+	afterAYa bool
+}
+
+// ruEndings объединяет группы окончаний и сортирует их по убыванию длины для поиска самого длинного.
+func ruEndings(afterAYa, plain []string) []ruEnding {
+	endings := make([]ruEnding, 0, len(afterAYa)+len(plain))
+	for _, s := range afterAYa {
+		endings = append(endings, ruEnding{suffix: []rune(s), afterAYa: true})
+	}
+	for _, s := range plain {
+		endings = append(endings, ruEnding{suffix: []rune(s)})
+	}
+	sort.SliceStable(endings, func(i, j int) bool {
+		return len(endings[i].suffix) > len(endings[j].suffix)
+	})
+	return endings
+}
+
+var (
+	ruPerfectiveGerundEndings = ruEndings(ruPerfectiveGerund1, ruPerfectiveGerund2)
+	ruAdjectiveEndings        = ruEndings(nil, ruAdjective)
+	ruParticipleEndings       = ruEndings(ruParticiple1, ruParticiple2)
+	ruReflexiveEndings        = ruEndings(nil, ruReflexive)
+	ruVerbEndings             = ruEndings(ruVerb1, ruVerb2)
+	ruNounEndings             = ruEndings(nil, ruNoun)
+	ruSuperlativeEndings      = ruEndings(nil, ruSuperlative)
+	ruDerivationalEndings     = ruEndings(nil, ruDerivational)
+)
+
+func isRussianVowel(r rune) bool {
+	return strings.ContainsRune("аеиоуыэюя", r)
+}
+
+// removeEnding удаляет самое длинное из окончаний, лежащее в области word[region:].
+// Как и among в Snowball, если самое длинное окончание не подходит по условию, удаления нет.
+func removeEnding(word []rune, region int, endings []ruEnding) ([]rune, bool) {
+	for _, e := range endings {
+		start := len(word) - len(e.suffix)
+		if start < region || string(word[start:]) != string(e.suffix) {
+			continue
+		}
+		if e.afterAYa && (start-1 < region || (word[start-1] != 'а' && word[start-1] != 'я')) {
+			return word, false
+		}
+		return word[:start], true
+	}
+	return word, false
+}
+
+// russianRegions возвращает начало области RV и области R2 по правилам Snowball.
+func russianRegions(word []rune) (rv, r2 int) {
+	rv = len(word)
+	for i, r := range word {
+		if isRussianVowel(r) {
+			rv = i + 1
+			break
+		}
+	}
+	r1 := nextRegion(word, 0)
+	return rv, nextRegion(word, r1)
+}
+
+// nextRegion - позиция после первой согласной, следующей за гласной, начиная с from.
+func nextRegion(word []rune, from int) int {
+	for i := from + 1; i < len(word); i++ {
+		if !isRussianVowel(word[i]) && isRussianVowel(word[i-1]) {
+			return i + 1
+		}
+	}
+	return len(word)
+}
+
+func russianStem(word string) string {
+	runes := []rune(strings.ReplaceAll(word, "ё", "е"))
+	rv, r2 := russianRegions(runes)
+
+	// Шаг 1: деепричастие, иначе возвратная частица и прилагательное, глагол или существительное
+	var ok bool
+	if runes, ok = removeEnding(runes, rv, ruPerfectiveGerundEndings); !ok {
+		runes, _ = removeEnding(runes, rv, ruReflexiveEndings)
+		if runes, ok = removeEnding(runes, rv, ruAdjectiveEndings); ok {
+			runes, _ = removeEnding(runes, rv, ruParticipleEndings)
+		} else if runes, ok = removeEnding(runes, rv, ruVerbEndings); !ok {
+			runes, _ = removeEnding(runes, rv, ruNounEndings)
+		}
+	}
+
+	// Шаг 2: окончание "и"
+	runes, _ = removeEnding(runes, rv, []ruEnding{{suffix: []rune("и")}})
+
+	// Шаг 3: словообразовательный суффикс в R2
+	runes, _ = removeEnding(runes, r2, ruDerivationalEndings)
+
+	// Шаг 4: превосходная степень, затем "нн" -> "н", иначе мягкий знак
+	runes, _ = removeEnding(runes, rv, ruSuperlativeEndings)
+	if hasSuffix(runes, rv, "нн") {
+		return string(runes[:len(runes)-1])
+	}
+	runes, _ = removeEnding(runes, rv, []ruEnding{{suffix: []rune("ь")}})
+	return string(runes)
+}
+
+func hasSuffix(word []rune, region int, suffix string) bool {
+	s := []rune(suffix)
+	start := len(word) - len(s)
+	return start >= region && string(word[start:]) == suffix
+}
@@ -0,0 +1,61 @@
+package hw03frequencyanalysis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnglishStemmer(t *testing.T) {
+	tests := map[string]string{
+		"caresses":        "caress",
+		"ponies":          "poni",
+		"cats":            "cat",
+		"agreed":          "agre",
+		"hopping":         "hop",
+		"filing":          "file",
+		"happy":           "happi",
+		"relational":      "relat",
+		"generalizations": "gener",
+		"hopefulness":     "hope",
+		"adjustment":      "adjust",
+		"adoption":        "adopt",
+		"controll":        "control",
+		"is":              "is",
+		"naïve":           "naïve",
+	}
+
+	for word, stem := range tests {
+		t.Run(word, func(t *testing.T) {
+			result, ok := EnglishStemmer.Normalize(word)
+			require.True(t, ok)
+			require.Equal(t, stem, result)
+		})
+	}
+}
+
+func TestRussianStemmer(t *testing.T) {
+	tests := map[string]string{
+		"вазы":          "ваз",
+		"книгами":       "книг",
+		"важнейшими":    "важн",
+		"возможности":   "возможн",
+		"известность":   "известн",
+		"красивые":      "красив",
+		"читали":        "чита",
+		"спускается":    "спуска",
+		"пересчитывая":  "пересчитыв",
+		"длинный":       "длин",
+		"ёлки":          "елк",
+		"строительство": "строительств",
+		"кристофер":     "кристофер",
+	}
+
+	for word, stem := range tests {
+		t.Run(word, func(t *testing.T) {
+			result, ok := RussianStemmer.Normalize(word)
+			require.True(t, ok)
+			require.Equal(t, stem, result)
+		})
+	}
+}
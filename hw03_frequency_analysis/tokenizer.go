@@ -0,0 +1,93 @@
+package hw03frequencyanalysis
+
+import (
+	"bufio"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Tokenizer splits the input into raw words. Split has the contract of bufio.SplitFunc,
+// so the same tokenizer serves both strings and streams.
+type Tokenizer interface {
+	Split(data []byte, atEOF bool) (advance int, token []byte, err error)
+}
+
+// TokenizerFunc adapts a bufio.SplitFunc to the Tokenizer interface.
+type TokenizerFunc bufio.SplitFunc
+
+func (f TokenizerFunc) Split(data []byte, atEOF bool) (int, []byte, error) {
+	return f(data, atEOF)
+}
+
+var (
+	// FieldsTokenizer splits the input on white space like strings.Fields. It is the default.
+	FieldsTokenizer Tokenizer = TokenizerFunc(bufio.ScanWords)
+	// WordTokenizer splits the input into words by a simplified UAX #29 word segmentation:
+	// a word is a run of letters, digits and marks, which may be joined by an apostrophe,
+	// a middle dot or a colon between letters, or by a dot or a comma between digits.
+	WordTokenizer Tokenizer = TokenizerFunc(scanSegmentedWords)
+)
+
+func scanSegmentedWords(data []byte, atEOF bool) (int, []byte, error) {
+	// Пропускаем все, что не может быть частью слова
+	start := 0
+	for start < len(data) {
+		if !atEOF && !utf8.FullRune(data[start:]) {
+			return start, nil, nil
+		}
+		r, size := utf8.DecodeRune(data[start:])
+		if isWordRune(r) {
+			break
+		}
+		start += size
+	}
+
+	var prev rune
+	for i := start; i < len(data); {
+		if !atEOF && !utf8.FullRune(data[i:]) {
+			return start, nil, nil
+		}
+		r, size := utf8.DecodeRune(data[i:])
+		if isWordRune(r) {
+			prev = r
+			i += size
+			continue
+		}
+
+		// Разделитель внутри слова допустим, только если за ним снова идет подходящая руна
+		next := i + size
+		if !atEOF && !utf8.FullRune(data[next:]) {
+			return start, nil, nil
+		}
+		nextRune, _ := utf8.DecodeRune(data[next:])
+		if next < len(data) && joinsWord(prev, r, nextRune) {
+			i = next
+			continue
+		}
+		return i + size, data[start:i], nil
+	}
+
+	if atEOF && start < len(data) {
+		return len(data), data[start:], nil
+	}
+	return start, nil, nil
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r) || r == '_'
+}
+
+// joinsWord - правила MidLetter, MidNum и MidNumLet из UAX #29.
+func joinsWord(prev, mid, next rune) bool {
+	bothLetters := unicode.IsLetter(prev) && unicode.IsLetter(next)
+	bothDigits := unicode.IsDigit(prev) && unicode.IsDigit(next)
+	switch mid {
+	case '\'', '’', '·', ':':
+		return bothLetters
+	case ',', ';':
+		return bothDigits
+	case '.':
+		return bothLetters || bothDigits
+	}
+	return false
+}
@@ -0,0 +1,53 @@
+package hw03frequencyanalysis
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/require"
+)
+
+func tokens(t *testing.T, tokenizer Tokenizer, input string) []string {
+	t.Helper()
+	// Читаем по байту, чтобы проверить работу на границах буфера
+	scanner := bufio.NewScanner(iotest.OneByteReader(strings.NewReader(input)))
+	scanner.Split(tokenizer.Split)
+	result := make([]string, 0)
+	for scanner.Scan() {
+		result = append(result, scanner.Text())
+	}
+	require.NoError(t, scanner.Err())
+	return result
+}
+
+func TestFieldsTokenizer(t *testing.T) {
+	require.Equal(t, strings.Fields(text), tokens(t, FieldsTokenizer, text))
+}
+
+func TestWordTokenizer(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []string
+	}{
+		{input: "", expected: []string{}},
+		{input: " ,.- ", expected: []string{}},
+		{input: "Нога, нога! 'нога'", expected: []string{"Нога", "нога", "нога"}},
+		{input: "какой-то dog,cat dog...cat", expected: []string{"какой", "то", "dog", "cat", "dog", "cat"}},
+		{input: "don't can’t e.g. 3.14 1,000,000", expected: []string{"don't", "can’t", "e.g", "3.14", "1,000,000"}},
+		{input: "snake_case x2 мир🙃мир", expected: []string{"snake_case", "x2", "мир", "мир"}},
+		{input: "é ends.", expected: []string{"é", "ends"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			require.Equal(t, tc.expected, tokens(t, WordTokenizer, tc.input))
+		})
+	}
+}
+
+func TestTopNWithTokenizer(t *testing.T) {
+	top := TopN("какой-то какой то, то", 2, WithTokenizer(WordTokenizer))
+	require.Equal(t, []WordCount{{Word: "то", Count: 3}, {Word: "какой", Count: 2}}, top)
+}
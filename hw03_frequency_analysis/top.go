@@ -1,64 +1,94 @@
 package hw03frequencyanalysis
 
 import (
-	"regexp"
 	"sort"
 	"strings"
 )
 
+// WordCount is a word together with the number of its occurrences in the text.
+// In approximate mode Count may overestimate the real frequency by at most Error.
+type WordCount struct {
+	Word  string
+	Count int
+	Error int
+}
+
 func Top10(str string) []string {
-	if str == "" {
-		return []string{}
+	top := TopN(str, 10)
+
+	// Заполняем итоговый результат только словами
+	result := make([]string, 0, len(top))
+	for _, v := range top {
+		result = append(result, v.Word)
 	}
+	return result
+}
 
-	// Сплитуем строку в слайс слов
-	words := strings.Fields(str)
+// TopN returns the n most frequent words of text with their counts.
+// Words with equal counts are ordered by the tie-break rule from opts, lexicographically by default.
+func TopN(text string, n int, opts ...Option) []WordCount {
+	if text == "" || n <= 0 {
+		return []WordCount{}
+	}
 
-	// Формируем шаблон для очистки слов от знаков препинания и пробелов по краям слова
-	cleanPattern := regexp.MustCompile(`^[\p{P}\s]+|[\p{P}\s]+$`)
+	// Разбиваем строку на слова и заполняем статистику частоты слов.
+	// Слово не может быть длиннее всего текста, поэтому ошибки чтения здесь нет
+	analyzer := NewAnalyzer(opts...)
+	_ = analyzer.scan(strings.NewReader(text), len(text)+1)
+	return analyzer.Top(n)
+}
 
-	// Заполняем мапу частоты слов, где ключ - слово, значение - частота
-	mapWordFreq := make(map[string]int, len(words))
-	for _, word := range words {
-		// Очищаем слово и приводим к нижнему регистру
-		cleanWord := cleanPattern.ReplaceAllString(word, "")
-		cleanWord = strings.ToLower(cleanWord)
-		// Если очищенное слово получилось "" или "-", то пропускаем его
-		if cleanWord == "" || cleanWord == "-" {
-			continue
-		}
-		mapWordFreq[cleanWord]++
-	}
+// wordStat - частота слова, порядковый номер его первого вхождения и возможная погрешность частоты.
+type wordStat struct {
+	count int
+	first int
+	err   int
+}
 
-	// Создаем структуру слово-частота, и заполняем слайс таких структур
-	type wordFreq struct {
-		word      string
-		frequency int
+// counter - способ подсчета частоты слов: точный или приближенный.
+type counter interface {
+	add(word string)
+	top(n int, cfg config) []WordCount
+}
+
+// wordStats - точная статистика слов, где ключ - слово.
+type wordStats map[string]*wordStat
+
+func (ws wordStats) add(word string) {
+	if stat, ok := ws[word]; ok {
+		stat.count++
+		return
 	}
-	slWordFreq := make([]wordFreq, 0, len(mapWordFreq))
-	for k, v := range mapWordFreq {
+	ws[word] = &wordStat{count: 1, first: len(ws)}
+}
+
+func (ws wordStats) top(n int, cfg config) []WordCount {
+	slWordFreq := make([]wordFreq, 0, len(ws))
+	for k, v := range ws {
 		slWordFreq = append(slWordFreq, wordFreq{k, v})
 	}
+	return rank(slWordFreq, n, cfg)
+}
+
+type wordFreq struct {
+	word string
+	stat *wordStat
+}
 
-	// Сортируем слайс структур
+// rank сортирует слова по частоте DESC с учетом правила разрешения равенства и возвращает первые n.
+func rank(slWordFreq []wordFreq, n int, cfg config) []WordCount {
 	sort.Slice(slWordFreq, func(i, j int) bool {
-		// Если есть слова с одинаковой частотой - сортируем по словам ASC
-		if slWordFreq[i].frequency == slWordFreq[j].frequency {
-			return slWordFreq[i].word < slWordFreq[j].word
+		// Если есть слова с одинаковой частотой - сортируем по правилу из опций
+		if slWordFreq[i].stat.count == slWordFreq[j].stat.count {
+			return cfg.less(slWordFreq[i].word, slWordFreq[i].stat, slWordFreq[j].word, slWordFreq[j].stat)
 		}
 		// Сортируем по частоте DESC
-		return slWordFreq[i].frequency > slWordFreq[j].frequency
+		return slWordFreq[i].stat.count > slWordFreq[j].stat.count
 	})
 
-	// Заполняем итоговый результат
-	result := make([]string, 0, len(slWordFreq))
-	for i, v := range slWordFreq {
-		// Прерываем если top10 уже набран
-		if i >= 10 {
-			return result
-		}
-		result = append(result, v.word)
+	result := make([]WordCount, 0, min(n, len(slWordFreq)))
+	for _, v := range slWordFreq[:min(n, len(slWordFreq))] {
+		result = append(result, WordCount{Word: v.word, Count: v.stat.count, Error: v.stat.err})
 	}
-
 	return result
 }
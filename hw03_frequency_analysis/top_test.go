@@ -1,6 +1,7 @@
 package hw03frequencyanalysis
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -111,3 +112,63 @@ func TestTop10(t *testing.T) {
 		}
 	})
 }
+
+func TestTopN(t *testing.T) {
+	t.Run("returns counts", func(t *testing.T) {
+		expected := []WordCount{
+			{Word: "word1", Count: 12},
+			{Word: "word7", Count: 4},
+			{Word: "word8", Count: 3},
+		}
+		require.Equal(t, expected, TopN(shortText, 3))
+	})
+
+	t.Run("n is greater than number of words", func(t *testing.T) {
+		require.Len(t, TopN(shortText, 100), 5)
+	})
+
+	t.Run("non positive n", func(t *testing.T) {
+		require.Empty(t, TopN(text, 0))
+		require.Empty(t, TopN(text, -1))
+	})
+
+	t.Run("Top10 is TopN without counts", func(t *testing.T) {
+		require.Equal(t, Top10(text), wordsOf(TopN(text, 10)))
+	})
+
+	t.Run("tie break by first occurrence", func(t *testing.T) {
+		expected := []WordCount{
+			{Word: "word1", Count: 12},
+			{Word: "word7", Count: 4},
+			{Word: "word8", Count: 3},
+			{Word: "word9", Count: 2},
+			{Word: "word10", Count: 2},
+		}
+		require.Equal(t, expected, TopN(shortText, 10, WithTieBreak(TieBreakFirstOccurrence)))
+	})
+
+	t.Run("tie break by collation", func(t *testing.T) {
+		input := "ёж жук ель яма"
+		// Побайтовое сравнение ставит "ё" после "я"
+		require.Equal(t, []string{"ель", "жук", "яма", "ёж"}, wordsOf(TopN(input, 10)))
+
+		// Коллатор русского алфавита: "ё" сразу после "е"
+		russian := func(a, b string) int {
+			key := strings.NewReplacer("ё", "е\uffff")
+			return strings.Compare(key.Replace(a), key.Replace(b))
+		}
+		require.Equal(t, []string{"ель", "ёж", "жук", "яма"}, wordsOf(TopN(input, 10, WithCollator(russian))))
+	})
+
+	t.Run("collation without collator is lexicographic", func(t *testing.T) {
+		require.Equal(t, TopN(text, 10), TopN(text, 10, WithTieBreak(TieBreakCollation)))
+	})
+}
+
+func wordsOf(top []WordCount) []string {
+	words := make([]string, 0, len(top))
+	for _, wc := range top {
+		words = append(words, wc.Word)
+	}
+	return words
+}
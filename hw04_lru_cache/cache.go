@@ -1,86 +1,434 @@
 package hw04lrucache
 
-import "sync"
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
 
 type Key string
 
-type Cache interface {
-	Set(key Key, value interface{}) bool
-	Get(key Key) (interface{}, bool)
+// GenericCache is a thread-safe cache with keys of type K and values of type V.
+type GenericCache[K comparable, V any] interface {
+	Set(key K, value V) bool
+	// SetWithTTL adds an entry that expires after ttl. Non-positive ttl means the entry never expires.
+	SetWithTTL(key K, value V, ttl time.Duration) bool
+	// TrySet is SetWithTTL that reports an entry the cache can never hold: it returns
+	// an error wrapping ErrTooHeavy if the entry weighs more than the whole capacity.
+	// Set and SetWithTTL drop such entries silently.
+	TrySet(key K, value V, ttl time.Duration) (bool, error)
+	Get(key K) (V, bool)
+	// GetOrLoad returns the cached value or calls loader and caches its result. Concurrent
+	// callers for the same key wait for a single load. A caller whose ctx is done stops
+	// waiting with ctx.Err(); the load is cancelled once nobody waits for it.
+	// Loader errors are returned to all waiters and cached if WithNegativeTTL is set.
+	// If the key is written or deleted during the load, the loaded value is returned
+	// to the waiters but not cached.
+	GetOrLoad(ctx context.Context, key K, loader Loader[K, V]) (V, error)
+	// Peek returns the value like Get but does not mark the entry as recently used.
+	Peek(key K) (V, bool)
+	// Delete removes the entry and reports whether it was in the cache.
+	Delete(key K) bool
+	// Len returns the number of entries, including expired ones that have not been removed yet.
+	Len() int
+	// Keys returns the keys of live entries from the most to the least recently used.
+	// With a policy other than PolicyLRU the keys are ordered from the most to the least
+	// valuable entry according to the policy.
+	Keys() []K
+	// Resize changes the capacity, evicting the least recently used entries when shrinking,
+	// and returns the number of evicted entries. Non-positive capacity is ignored.
+	// The capacity of a weighted cache is its maximum total weight. A sharded cache keeps
+	// at least one entry per shard, so it rounds a capacity below the shard count up to it.
+	Resize(capacity int) int
 	Clear()
+	// Close stops the background janitor. The cache stays usable after Close.
+	Close() error
+	// OnEvict registers a hook called for every entry that leaves the cache. Hooks run
+	// outside the cache lock, so they may call the cache. Values replaced by Set are not reported.
+	OnEvict(fn func(key K, value V, reason EvictReason))
+	// Stats returns a snapshot of the hit, miss, eviction and write counters.
+	Stats() Stats
+	// Snapshot writes live entries with their expiration time using the codec set by WithCodec,
+	// from the most to the least recently used.
+	Snapshot(w io.Writer) error
+	// Restore adds entries written by Snapshot on top of the current contents, keeping their
+	// recency order. Entries that expired since the snapshot are skipped.
+	Restore(r io.Reader) error
 }
 
-type lruCache struct {
-	capacity      int
-	queue         List
-	items         map[Key]*ListItem
-	itemsReversed map[*ListItem]Key
-	mutex         sync.Mutex
+// Cache is the untyped cache kept for compatibility with the original API.
+type Cache = GenericCache[Key, interface{}]
+
+// cacheEntry - элемент кэша: хранит ключ, чтобы при вытеснении удалить его из словаря,
+// и служебные поля политики вытеснения.
+type cacheEntry[K comparable, V any] struct {
+	key       K
+	value     V
+	expiresAt time.Time // Нулевое время - элемент не устаревает
+	weight    int64
+
+	node    *GenericListItem[*cacheEntry[K, V]] // Позиция в списке политики
+	segment segment                             // Список политики, в котором находится элемент
+	freq    int                                 // Частота обращений для LFU
+	tick    uint64                              // Время последнего обращения для LFU
+	index   int                                 // Позиция в куче LFU
+}
+
+func (e *cacheEntry[K, V]) expired(now time.Time) bool {
+	return !e.expiresAt.IsZero() && !now.Before(e.expiresAt)
+}
+
+// cache - потокобезопасный кэш, порядок вытеснения которого определяет политика.
+type cache[K comparable, V any] struct {
+	capacity int64 // Максимальный суммарный вес; без Weigher вес каждого элемента равен 1
+	weight   int64
+	weigher  Weigher[K, V]
+	policy   policy[K, V]
+	items    map[K]*cacheEntry[K, V]
+	mutex    sync.Mutex
+
+	defaultTTL time.Duration
+	clock      Clock
+	janitor    *janitor
+	codec      Codec
+
+	hooks   []func(key K, value V, reason EvictReason)
+	evicted []eviction[K, V] // Вытеснения, накопленные под блокировкой
+
+	loads       map[K]*loadCall[V]
+	failures    map[K]loadFailure
+	negativeTTL time.Duration
+
+	stats counters
+}
+
+func (c *cache[K, V]) Set(key K, value V) bool {
+	return c.SetWithTTL(key, value, c.defaultTTL)
 }
 
-func (lc *lruCache) Set(key Key, value interface{}) bool {
+func (c *cache[K, V]) SetWithTTL(key K, value V, ttl time.Duration) bool {
+	existed, _ := c.TrySet(key, value, ttl)
+	return existed
+}
+
+func (c *cache[K, V]) TrySet(key K, value V, ttl time.Duration) (bool, error) {
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = c.clock.Now().Add(ttl)
+	}
+	weight := c.weigh(key, value)
+
 	// Блокируем мютекс для потокобезопасности и разблокируем в отложенном вызове
-	lc.mutex.Lock()
-	defer lc.mutex.Unlock()
+	c.mutex.Lock()
+	defer c.unlock()
 
-	// если элемент присутствует в словаре, то обновить его значение
-	// и переместить элемент в начало очереди
-	if item, exists := lc.items[key]; exists {
-		item.Value = value
-		lc.queue.MoveToFront(item)
-		return true
+	return c.set(key, value, expiresAt, weight)
+}
+
+// set добавляет или обновляет элемент с уже вычисленными сроком жизни и весом.
+// Вызывается под блокировкой.
+func (c *cache[K, V]) set(key K, value V, expiresAt time.Time, weight int64) (bool, error) {
+	c.supersedeLoad(key)
+	entry, exists := c.items[key]
+
+	// Элемент тяжелее всего кэша не сохраняем, а прежнее значение по ключу удаляем,
+	// чтобы не возвращать устаревшие данные
+	if weight > c.capacity {
+		if exists {
+			c.remove(entry, EvictCapacity)
+		}
+		return false, fmt.Errorf("%w: weight %d exceeds capacity %d", ErrTooHeavy, weight, c.capacity)
 	}
 
-	// Если размер очереди станет больше  емкости
-	// Удаляем последний элемент очереди и его значение из словаря
-	if lc.queue.Len()+1 > lc.capacity {
-		itemToDelete := lc.queue.Back()
-		itemKeyToDelete := lc.itemsReversed[itemToDelete]
-		delete(lc.items, itemKeyToDelete)
-		delete(lc.itemsReversed, itemToDelete)
-		lc.queue.Remove(itemToDelete)
+	// если элемент присутствует в словаре, то обновить его значение
+	// и отметить обращение к нему
+	if exists {
+		// Устаревший элемент считается отсутствующим
+		existed := !c.isExpired(entry)
+		if existed {
+			c.stats.updates.Add(1)
+		} else {
+			c.stats.sets.Add(1)
+			c.addEviction(entry, EvictExpired)
+		}
+		entry.value = value
+		entry.expiresAt = expiresAt
+		c.policy.hit(entry)
+
+		// Вес мог вырасти - вытесняем лишнее, как и при добавлении
+		if oldWeight := entry.weight; oldWeight != weight {
+			entry.weight = weight
+			c.weight += weight - oldWeight
+			c.policy.reweigh(entry, oldWeight)
+			// Обновленный элемент помещается в кэш, поэтому вытесняем только другие
+			c.evictOverflow(c.capacity, entry)
+		}
+		return existed, nil
 	}
 
-	// Вставляем элемент в очередь и добавляем в элементы кэша
-	newListItem := lc.queue.PushFront(value)
-	lc.items[key] = newListItem
-	lc.itemsReversed[newListItem] = key
+	// Если вес кэша станет больше емкости
+	// вытесняем элементы, выбранные политикой, пока новый не поместится
+	c.evictOverflow(c.capacity-weight, nil)
+
+	// Добавляем элемент в политику и в словарь
+	entry = &cacheEntry[K, V]{key: key, value: value, expiresAt: expiresAt, weight: weight}
+	c.policy.add(entry)
+	c.items[key] = entry
+	c.weight += weight
+	c.stats.sets.Add(1)
 
-	return false
+	return false, nil
 }
 
-func (lc *lruCache) Get(key Key) (interface{}, bool) {
+func (c *cache[K, V]) Get(key K) (V, bool) {
 	// Блокируем мютекс для потокобезопасности и разблокируем в отложенном вызове
-	lc.mutex.Lock()
-	defer lc.mutex.Unlock()
+	c.mutex.Lock()
+	defer c.unlock()
 
+	return c.get(key)
+}
+
+// get ищет элемент и учитывает попадание или промах. Вызывается под блокировкой.
+func (c *cache[K, V]) get(key K) (V, bool) {
 	// Если ключ сущаствует - возвращаем значение элемента и false
-	if item, keyExist := lc.items[key]; keyExist {
-		lc.queue.MoveToFront(item)
-		return item.Value, true
+	if entry, keyExist := c.items[key]; keyExist {
+		// Устаревший элемент удаляем при обращении к нему
+		if c.isExpired(entry) {
+			c.remove(entry, EvictExpired)
+			c.stats.misses.Add(1)
+			var zero V
+			return zero, false
+		}
+		c.policy.hit(entry)
+		c.stats.hits.Add(1)
+		return entry.value, true
+	}
+
+	c.stats.misses.Add(1)
+	var zero V
+	return zero, false
+}
+
+func (c *cache[K, V]) Peek(key K) (V, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// В отличие от Get, не отмечаем обращение и не удаляем устаревший элемент
+	if entry, exists := c.items[key]; exists && !c.isExpired(entry) {
+		return entry.value, true
+	}
+
+	var zero V
+	return zero, false
+}
+
+func (c *cache[K, V]) Delete(key K) bool {
+	c.mutex.Lock()
+	defer c.unlock()
+
+	// Удаление ключа сбрасывает и сохраненную ошибку его загрузки
+	delete(c.failures, key)
+	c.supersedeLoad(key)
+
+	entry, exists := c.items[key]
+	if !exists {
+		return false
+	}
+	if c.isExpired(entry) {
+		c.remove(entry, EvictExpired)
+		return false
+	}
+	c.remove(entry, EvictDeleted)
+	return true
+}
+
+func (c *cache[K, V]) Len() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return len(c.items)
+}
+
+func (c *cache[K, V]) Keys() []K {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	now := c.clock.Now()
+	keys := make([]K, 0, len(c.items))
+	for entry := range c.policy.all() {
+		if !entry.expired(now) {
+			keys = append(keys, entry.key)
+		}
+	}
+	return keys
+}
+
+func (c *cache[K, V]) Resize(capacity int) int {
+	if capacity <= 0 {
+		return 0
 	}
 
-	return nil, false
+	c.mutex.Lock()
+	defer c.unlock()
+
+	// При уменьшении емкости вытесняем элементы, выбранные политикой
+	c.capacity = int64(capacity)
+	c.policy.setCapacity(c.capacity)
+	return c.evictOverflow(c.capacity, nil)
 }
 
-func (lc *lruCache) Clear() {
+func (c *cache[K, V]) Clear() {
 	// Блокируем мютекс для потокобезопасности и разблокируем в отложенном вызове
-	lc.mutex.Lock()
-	defer lc.mutex.Unlock()
+	c.mutex.Lock()
+	defer c.unlock()
+
+	// Обработчики вызываются для каждого элемента, поэтому очистка становится O(n)
+	if len(c.hooks) > 0 {
+		for entry := range c.policy.all() {
+			c.addEviction(entry, EvictCleared)
+		}
+	}
 
-	lc.queue = NewList()
-	lc.items = make(map[Key]*ListItem, lc.capacity)
-	lc.itemsReversed = make(map[*ListItem]Key, lc.capacity)
+	c.policy.reset()
+	c.items = make(map[K]*cacheEntry[K, V], len(c.items))
+	c.weight = 0
+	c.failures = make(map[K]loadFailure)
+	// Выполняющиеся загрузки не должны вернуть в кэш значения, загруженные до очистки
+	for _, call := range c.loads {
+		call.superseded = true
+	}
+}
+
+func (c *cache[K, V]) Close() error {
+	if c.janitor != nil {
+		c.janitor.stop()
+	}
+	return nil
 }
 
-func NewCache(capacity int) Cache {
+// removeExpired удаляет все устаревшие элементы, вызывается сборщиком.
+func (c *cache[K, V]) removeExpired() {
+	c.mutex.Lock()
+	defer c.unlock()
+
+	now := c.clock.Now()
+	for _, entry := range c.items {
+		if entry.expired(now) {
+			c.remove(entry, EvictExpired)
+		}
+	}
+	for key, failure := range c.failures {
+		if !now.Before(failure.expiresAt) {
+			delete(c.failures, key)
+		}
+	}
+}
+
+// isExpired не запрашивает время у часов для элементов без срока жизни.
+func (c *cache[K, V]) isExpired(entry *cacheEntry[K, V]) bool {
+	return !entry.expiresAt.IsZero() && entry.expired(c.clock.Now())
+}
+
+func (c *cache[K, V]) OnEvict(fn func(key K, value V, reason EvictReason)) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.hooks = append(c.hooks, fn)
+}
+
+func (c *cache[K, V]) Stats() Stats {
+	return c.stats.snapshot()
+}
+
+func (c *cache[K, V]) remove(entry *cacheEntry[K, V], reason EvictReason) {
+	c.addEviction(entry, reason)
+	delete(c.items, entry.key)
+	c.weight -= entry.weight
+	c.policy.remove(entry, reason)
+}
+
+// evictOverflow вытесняет элементы, кроме keep, пока суммарный вес больше limit,
+// и возвращает их число. Вес keep не должен превышать limit.
+func (c *cache[K, V]) evictOverflow(limit int64, keep *cacheEntry[K, V]) int {
+	evicted := 0
+	for c.weight > limit && len(c.items) > 0 {
+		c.remove(c.policy.victim(keep), EvictCapacity)
+		evicted++
+	}
+	return evicted
+}
+
+// weigh вычисляет вес элемента; вес меньше единицы считается единицей,
+// иначе кэш мог бы расти неограниченно.
+func (c *cache[K, V]) weigh(key K, value V) int64 {
+	if c.weigher == nil {
+		return 1
+	}
+	return max(1, c.weigher(key, value))
+}
+
+// addEviction учитывает вытесненный элемент и запоминает его, если есть кому о нем сообщить.
+func (c *cache[K, V]) addEviction(entry *cacheEntry[K, V], reason EvictReason) {
+	if reason == EvictCapacity || reason == EvictExpired {
+		c.stats.evictions.Add(1)
+	}
+	if len(c.hooks) > 0 {
+		c.evicted = append(c.evicted, eviction[K, V]{key: entry.key, value: entry.value, reason: reason})
+	}
+}
+
+// unlock снимает блокировку и только после этого вызывает обработчики вытеснения,
+// чтобы обращение обработчика к кэшу не приводило к взаимоблокировке.
+func (c *cache[K, V]) unlock() {
+	evicted, hooks := c.evicted, c.hooks
+	c.evicted = nil
+	c.mutex.Unlock()
+
+	for _, e := range evicted {
+		for _, hook := range hooks {
+			hook(e.key, e.value, e.reason)
+		}
+	}
+}
+
+// NewGenericCache returns a cache holding at most capacity entries, or nil if capacity
+// is not positive. Entries are evicted in LRU order unless WithPolicy selects another policy.
+func NewGenericCache[K comparable, V any](capacity int, opts ...Option) GenericCache[K, V] {
 	if capacity <= 0 {
 		return nil
 	}
-	return &lruCache{
-		capacity:      capacity,
-		queue:         NewList(),
-		items:         make(map[Key]*ListItem, capacity),
-		itemsReversed: make(map[*ListItem]Key, capacity),
+	o := newOptions(opts)
+	c := newCache[K, V](int64(capacity), nil, o)
+	if o.janitorInterval > 0 {
+		c.janitor = startJanitor(o.clock, o.janitorInterval, c.removeExpired)
 	}
+	return c
+}
+
+// newCache создает кэш без сборщика: его запускает вызывающий код.
+func newCache[K comparable, V any](capacity int64, weigher Weigher[K, V], o options) *cache[K, V] {
+	// Без Weigher емкость - это число элементов, и словарь можно выделить сразу
+	var sizeHint int64
+	if weigher == nil {
+		sizeHint = capacity
+	}
+	return &cache[K, V]{
+		capacity:   capacity,
+		weigher:    weigher,
+		policy:     newPolicy[K, V](o.policy, capacity),
+		items:      make(map[K]*cacheEntry[K, V], sizeHint),
+		defaultTTL: o.defaultTTL,
+		clock:      o.clock,
+		codec:      o.codec,
+
+		loads:       make(map[K]*loadCall[V]),
+		failures:    make(map[K]loadFailure),
+		negativeTTL: o.negativeTTL,
+	}
+}
+
+func NewCache(capacity int, opts ...Option) Cache {
+	return NewGenericCache[Key, interface{}](capacity, opts...)
 }
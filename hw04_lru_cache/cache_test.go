@@ -5,6 +5,7 @@ import (
 	"strconv"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -93,6 +94,201 @@ func TestCache(t *testing.T) {
 	})
 }
 
+func TestGenericCache(t *testing.T) {
+	t.Run("typed values", func(t *testing.T) {
+		c := NewGenericCache[string, int](2)
+
+		require.False(t, c.Set("aaa", 100))
+		require.False(t, c.Set("bbb", 200))
+
+		val, ok := c.Get("aaa")
+		require.True(t, ok)
+		require.Equal(t, 100, val)
+
+		require.False(t, c.Set("ccc", 300)) // "bbb" вытесняется как давно используемый
+		val, ok = c.Get("bbb")
+		require.False(t, ok)
+		require.Zero(t, val)
+	})
+
+	t.Run("struct keys", func(t *testing.T) {
+		type point struct{ x, y int }
+		c := NewGenericCache[point, []string](3)
+
+		c.Set(point{1, 2}, []string{"a"})
+		require.True(t, c.Set(point{1, 2}, []string{"b", "c"}))
+
+		val, ok := c.Get(point{1, 2})
+		require.True(t, ok)
+		require.Equal(t, []string{"b", "c"}, val)
+
+		_, ok = c.Get(point{2, 1})
+		require.False(t, ok)
+	})
+
+	t.Run("bad capacity", func(t *testing.T) {
+		require.Nil(t, NewGenericCache[int, int](0))
+	})
+
+	t.Run("untyped cache is an instance", func(t *testing.T) {
+		var c GenericCache[Key, interface{}] = NewCache(1)
+		c.Set("k", 1)
+		val, ok := c.Get("k")
+		require.True(t, ok)
+		require.Equal(t, 1, val)
+	})
+}
+
+func TestCacheTTL(t *testing.T) {
+	t.Run("lazy expiration on get", func(t *testing.T) {
+		clock := newFakeClock()
+		c := NewCache(10, WithClock(clock))
+
+		c.SetWithTTL("aaa", 100, time.Minute)
+		c.Set("bbb", 200) // без срока жизни
+
+		clock.Advance(time.Minute - time.Nanosecond)
+		val, ok := c.Get("aaa")
+		require.True(t, ok)
+		require.Equal(t, 100, val)
+
+		clock.Advance(time.Nanosecond)
+		val, ok = c.Get("aaa")
+		require.False(t, ok)
+		require.Nil(t, val)
+
+		clock.Advance(24 * time.Hour)
+		_, ok = c.Get("bbb")
+		require.True(t, ok)
+	})
+
+	t.Run("default ttl", func(t *testing.T) {
+		clock := newFakeClock()
+		c := NewCache(10, WithClock(clock), WithDefaultTTL(time.Second))
+
+		c.Set("aaa", 100)
+		c.SetWithTTL("bbb", 200, time.Hour)
+		c.SetWithTTL("ccc", 300, 0) // ttl 0 отменяет срок жизни по умолчанию
+
+		clock.Advance(time.Second)
+		_, ok := c.Get("aaa")
+		require.False(t, ok)
+		_, ok = c.Get("bbb")
+		require.True(t, ok)
+		_, ok = c.Get("ccc")
+		require.True(t, ok)
+	})
+
+	t.Run("set refreshes ttl", func(t *testing.T) {
+		clock := newFakeClock()
+		c := NewCache(10, WithClock(clock))
+
+		c.SetWithTTL("aaa", 100, time.Minute)
+		clock.Advance(30 * time.Second)
+		require.True(t, c.SetWithTTL("aaa", 200, time.Minute))
+
+		clock.Advance(45 * time.Second)
+		val, ok := c.Get("aaa")
+		require.True(t, ok)
+		require.Equal(t, 200, val)
+	})
+
+	t.Run("expired entry is reported as absent by set", func(t *testing.T) {
+		clock := newFakeClock()
+		c := NewCache(10, WithClock(clock))
+
+		c.SetWithTTL("aaa", 100, time.Minute)
+		clock.Advance(time.Minute)
+		require.False(t, c.Set("aaa", 200))
+
+		val, ok := c.Get("aaa")
+		require.True(t, ok)
+		require.Equal(t, 200, val)
+	})
+}
+
+func TestCacheOperations(t *testing.T) {
+	t.Run("peek does not promote", func(t *testing.T) {
+		c := NewCache(2)
+		c.Set("k1", 1)
+		c.Set("k2", 2)
+
+		val, ok := c.Peek("k1")
+		require.True(t, ok)
+		require.Equal(t, 1, val)
+
+		c.Set("k3", 3) // k1 остался наиболее давно используемым
+		_, ok = c.Peek("k1")
+		require.False(t, ok)
+		require.Equal(t, []Key{"k3", "k2"}, c.Keys())
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		c := NewCache(3)
+		c.Set("k1", 1)
+		c.Set("k2", 2)
+
+		require.True(t, c.Delete("k1"))
+		require.False(t, c.Delete("k1"))
+		require.False(t, c.Delete("missing"))
+
+		_, ok := c.Get("k1")
+		require.False(t, ok)
+		require.Equal(t, 1, c.Len())
+	})
+
+	t.Run("keys in recency order", func(t *testing.T) {
+		c := NewCache(4)
+		require.Empty(t, c.Keys())
+
+		c.Set("k1", 1)
+		c.Set("k2", 2)
+		c.Set("k3", 3)
+		c.Get("k1")
+		c.Set("k2", 20)
+
+		require.Equal(t, []Key{"k2", "k1", "k3"}, c.Keys())
+		require.Equal(t, 3, c.Len())
+	})
+
+	t.Run("expired entries", func(t *testing.T) {
+		clock := newFakeClock()
+		c := NewCache(4, WithClock(clock))
+		c.SetWithTTL("k1", 1, time.Minute)
+		c.Set("k2", 2)
+		clock.Advance(time.Minute)
+
+		_, ok := c.Peek("k1")
+		require.False(t, ok)
+		require.Equal(t, []Key{"k2"}, c.Keys())
+		require.Equal(t, 2, c.Len()) // Еще не удален
+
+		require.False(t, c.Delete("k1"))
+		require.Equal(t, 1, c.Len())
+	})
+
+	t.Run("resize", func(t *testing.T) {
+		c := NewCache(4)
+		for i := 1; i <= 4; i++ {
+			c.Set(Key("k"+strconv.Itoa(i)), i)
+		}
+		c.Get("k1")
+
+		require.Equal(t, 2, c.Resize(2))
+		require.Equal(t, []Key{"k1", "k4"}, c.Keys())
+
+		c.Set("k5", 5)
+		require.Equal(t, []Key{"k5", "k1"}, c.Keys())
+
+		require.Equal(t, 0, c.Resize(3))
+		c.Set("k6", 6)
+		require.Equal(t, []Key{"k6", "k5", "k1"}, c.Keys())
+
+		require.Equal(t, 0, c.Resize(0))
+		require.Equal(t, 3, c.Len())
+	})
+}
+
 func TestCacheMultithreading(t *testing.T) {
 	c := NewCache(10)
 	wg := &sync.WaitGroup{}
@@ -116,3 +312,143 @@ func TestCacheMultithreading(t *testing.T) {
 
 	require.NotNil(t, c)
 }
+
+func TestCacheAllocations(t *testing.T) {
+	const capacity = 64
+	allocs := func(newWorkload func(capacity int) func(i int)) float64 {
+		step := newWorkload(capacity)
+		// Прогреваем кэш, чтобы каждая вставка вытесняла элемент
+		for i := range capacity * 4 {
+			step(i)
+		}
+		// AllocsPerRun округляет среднее вниз, поэтому за один прогон выполняем
+		// и вставку, и обновление ключа
+		i := capacity * 4
+		return testing.AllocsPerRun(1000, func() {
+			step(i)
+			step(i + 1)
+			i += 2
+		})
+	}
+
+	// Исходная реализация упаковывает в interface{} каждое записываемое значение,
+	// типизированный кэш выделяет память только под новые элементы
+	require.Less(t, allocs(genericWorkload), allocs(baselineWorkload))
+}
+
+// baselineCache - копия исходной реализации кэша на interface{} до перехода на дженерики,
+// чтобы сравнивать с ней число аллокаций.
+type baselineCache struct {
+	capacity      int
+	queue         List
+	items         map[Key]*ListItem
+	itemsReversed map[*ListItem]Key
+	mutex         sync.Mutex
+}
+
+func newBaselineCache(capacity int) *baselineCache {
+	return &baselineCache{
+		capacity:      capacity,
+		queue:         NewList(),
+		items:         make(map[Key]*ListItem, capacity),
+		itemsReversed: make(map[*ListItem]Key, capacity),
+	}
+}
+
+func (lc *baselineCache) Set(key Key, value interface{}) bool {
+	lc.mutex.Lock()
+	defer lc.mutex.Unlock()
+
+	if item, exists := lc.items[key]; exists {
+		item.Value = value
+		lc.queue.MoveToFront(item)
+		return true
+	}
+
+	if lc.queue.Len()+1 > lc.capacity {
+		itemToDelete := lc.queue.Back()
+		itemKeyToDelete := lc.itemsReversed[itemToDelete]
+		delete(lc.items, itemKeyToDelete)
+		delete(lc.itemsReversed, itemToDelete)
+		lc.queue.Remove(itemToDelete)
+	}
+
+	newListItem := lc.queue.PushFront(value)
+	lc.items[key] = newListItem
+	lc.itemsReversed[newListItem] = key
+	return false
+}
+
+func (lc *baselineCache) Get(key Key) (interface{}, bool) {
+	lc.mutex.Lock()
+	defer lc.mutex.Unlock()
+
+	if item, keyExist := lc.items[key]; keyExist {
+		lc.queue.MoveToFront(item)
+		return item.Value, true
+	}
+	return nil, false
+}
+
+// cacheWorkload - общая нагрузка для сравнения реализаций: каждый ключ запрашивается
+// и записывается дважды подряд. Первый раз это промах и вставка с вытеснением,
+// второй - попадание и обновление значения.
+func cacheWorkload(keys []Key, get func(Key) bool, set func(Key, int)) func(i int) {
+	return func(i int) {
+		key := keys[(i/2)%len(keys)]
+		get(key)
+		// Значения больше 255 не берутся из статического кэша рантайма и упаковываются с аллокацией
+		set(key, 1000+i)
+	}
+}
+
+func benchmarkKeys(capacity int) []Key {
+	keys := make([]Key, capacity*2)
+	for i := range keys {
+		keys[i] = Key(strconv.Itoa(i))
+	}
+	return keys
+}
+
+func baselineWorkload(capacity int) func(i int) {
+	c := newBaselineCache(capacity)
+	return cacheWorkload(benchmarkKeys(capacity),
+		func(key Key) bool { _, ok := c.Get(key); return ok },
+		func(key Key, value int) { c.Set(key, value) })
+}
+
+func genericWorkload(capacity int) func(i int) {
+	c := NewGenericCache[Key, int](capacity)
+	return cacheWorkload(benchmarkKeys(capacity),
+		func(key Key) bool { _, ok := c.Get(key); return ok },
+		func(key Key, value int) { c.Set(key, value) })
+}
+
+func untypedWorkload(capacity int) func(i int) {
+	c := NewCache(capacity)
+	return cacheWorkload(benchmarkKeys(capacity),
+		func(key Key) bool { _, ok := c.Get(key); return ok },
+		func(key Key, value int) { c.Set(key, value) })
+}
+
+func BenchmarkCache(b *testing.B) {
+	const capacity = 1024
+	workloads := []struct {
+		name string
+		new  func(capacity int) func(i int)
+	}{
+		{name: "baseline", new: baselineWorkload},
+		{name: "untyped", new: untypedWorkload},
+		{name: "generic", new: genericWorkload},
+	}
+
+	for _, w := range workloads {
+		b.Run(w.name, func(b *testing.B) {
+			step := w.new(capacity)
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				step(i)
+			}
+		})
+	}
+}
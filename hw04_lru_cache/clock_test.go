@@ -0,0 +1,67 @@
+package hw04lrucache
+
+import (
+	"sync"
+	"time"
+)
+
+// fakeClock - управляемые часы для тестов: время сдвигается только через Advance,
+// а тики сборщика отправляются через Tick.
+type fakeClock struct {
+	mutex  sync.Mutex
+	now    time.Time
+	ticker *fakeTicker
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func (c *fakeClock) NewTicker(time.Duration) Ticker {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.ticker = &fakeTicker{ch: make(chan time.Time)}
+	return c.ticker
+}
+
+// Tick блокируется, пока тик не будет получен сборщиком.
+func (c *fakeClock) Tick() {
+	c.mutex.Lock()
+	ticker, now := c.ticker, c.now
+	c.mutex.Unlock()
+	ticker.ch <- now
+}
+
+type fakeTicker struct {
+	ch      chan time.Time
+	mutex   sync.Mutex
+	stopped bool
+}
+
+func (t *fakeTicker) C() <-chan time.Time {
+	return t.ch
+}
+
+func (t *fakeTicker) Stop() {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.stopped = true
+}
+
+func (t *fakeTicker) Stopped() bool {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return t.stopped
+}
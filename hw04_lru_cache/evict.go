@@ -0,0 +1,36 @@
+package hw04lrucache
+
+// EvictReason tells why an entry left the cache.
+type EvictReason int
+
+const (
+	// EvictCapacity means the entry was the least recently used one when the cache was full.
+	EvictCapacity EvictReason = iota
+	// EvictExpired means the entry outlived its TTL.
+	EvictExpired
+	// EvictDeleted means the entry was explicitly removed from the cache.
+	EvictDeleted
+	// EvictCleared means the entry was removed by Clear.
+	EvictCleared
+)
+
+func (r EvictReason) String() string {
+	switch r {
+	case EvictCapacity:
+		return "capacity"
+	case EvictExpired:
+		return "expired"
+	case EvictDeleted:
+		return "deleted"
+	case EvictCleared:
+		return "cleared"
+	}
+	return "unknown"
+}
+
+// eviction - вытесненный элемент, обработчики для которого вызываются после снятия блокировки.
+type eviction[K comparable, V any] struct {
+	key    K
+	value  V
+	reason EvictReason
+}
@@ -0,0 +1,129 @@
+package hw04lrucache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type evictRecord struct {
+	key    string
+	value  int
+	reason EvictReason
+}
+
+func recordEvictions(c GenericCache[string, int]) *[]evictRecord {
+	records := &[]evictRecord{}
+	c.OnEvict(func(key string, value int, reason EvictReason) {
+		*records = append(*records, evictRecord{key: key, value: value, reason: reason})
+	})
+	return records
+}
+
+func TestOnEvict(t *testing.T) {
+	t.Run("capacity", func(t *testing.T) {
+		c := NewGenericCache[string, int](2)
+		records := recordEvictions(c)
+
+		c.Set("k1", 1)
+		c.Set("k2", 2)
+		c.Get("k1")
+		c.Set("k3", 3) // вытесняется k2
+		c.Set("k1", 10)
+
+		require.Equal(t, []evictRecord{{key: "k2", value: 2, reason: EvictCapacity}}, *records)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		clock := newFakeClock()
+		c := NewGenericCache[string, int](10, WithClock(clock), WithJanitor(time.Second))
+		defer c.Close()
+		records := recordEvictions(c)
+
+		c.SetWithTTL("lazy", 1, time.Minute)
+		c.SetWithTTL("janitor", 2, time.Minute)
+		c.SetWithTTL("overwritten", 3, time.Minute)
+		clock.Advance(time.Minute)
+
+		c.Get("lazy")
+		c.Set("overwritten", 30)
+		clock.Tick()
+		clock.Tick()
+
+		require.Equal(t, []evictRecord{
+			{key: "lazy", value: 1, reason: EvictExpired},
+			{key: "overwritten", value: 3, reason: EvictExpired},
+			{key: "janitor", value: 2, reason: EvictExpired},
+		}, *records)
+	})
+
+	t.Run("delete and resize", func(t *testing.T) {
+		c := NewGenericCache[string, int](3)
+		records := recordEvictions(c)
+
+		c.Set("k1", 1)
+		c.Set("k2", 2)
+		c.Set("k3", 3)
+		c.Delete("k2")
+		c.Resize(1)
+
+		require.Equal(t, []evictRecord{
+			{key: "k2", value: 2, reason: EvictDeleted},
+			{key: "k1", value: 1, reason: EvictCapacity},
+		}, *records)
+	})
+
+	t.Run("clear fires for every entry", func(t *testing.T) {
+		c := NewGenericCache[string, int](10)
+		records := recordEvictions(c)
+
+		c.Set("k1", 1)
+		c.Set("k2", 2)
+		c.Set("k3", 3)
+		c.Clear()
+
+		require.ElementsMatch(t, []evictRecord{
+			{key: "k1", value: 1, reason: EvictCleared},
+			{key: "k2", value: 2, reason: EvictCleared},
+			{key: "k3", value: 3, reason: EvictCleared},
+		}, *records)
+
+		c.Clear()
+		require.Len(t, *records, 3)
+	})
+
+	t.Run("hooks may call the cache", func(t *testing.T) {
+		c := NewGenericCache[string, int](1)
+		var stillCached []bool
+		c.OnEvict(func(key string, _ int, _ EvictReason) {
+			// Под блокировкой этот вызов привел бы к взаимоблокировке
+			_, ok := c.Get(key)
+			stillCached = append(stillCached, ok)
+		})
+
+		c.Set("k1", 1)
+		c.Set("k2", 2)
+
+		require.Equal(t, []bool{false}, stillCached)
+	})
+
+	t.Run("several hooks", func(t *testing.T) {
+		c := NewGenericCache[string, int](1)
+		first, second := recordEvictions(c), recordEvictions(c)
+
+		c.Set("k1", 1)
+		c.Set("k2", 2)
+
+		require.Len(t, *first, 1)
+		require.Equal(t, *first, *second)
+	})
+}
+
+func TestEvictReasonString(t *testing.T) {
+	require.Equal(t, "capacity", EvictCapacity.String())
+	require.Equal(t, "expired", EvictExpired.String())
+	require.Equal(t, "deleted", EvictDeleted.String())
+	require.Equal(t, "cleared", EvictCleared.String())
+	require.Equal(t, "unknown", EvictReason(42).String())
+}
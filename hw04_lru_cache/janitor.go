@@ -0,0 +1,41 @@
+package hw04lrucache
+
+import (
+	"sync"
+	"time"
+)
+
+// janitor - фоновая горутина, периодически удаляющая устаревшие элементы кэша.
+type janitor struct {
+	done     chan struct{}
+	wg       sync.WaitGroup
+	stopOnce sync.Once
+}
+
+func startJanitor(clock Clock, interval time.Duration, cleanup func()) *janitor {
+	j := &janitor{done: make(chan struct{})}
+	ticker := clock.NewTicker(interval)
+
+	j.wg.Add(1)
+	go func() {
+		defer j.wg.Done()
+		defer ticker.Stop()
+		for {
+			select {
+			case <-j.done:
+				return
+			case <-ticker.C():
+				cleanup()
+			}
+		}
+	}()
+	return j
+}
+
+// stop останавливает горутину и дожидается ее завершения; повторные вызовы ничего не делают.
+func (j *janitor) stop() {
+	j.stopOnce.Do(func() {
+		close(j.done)
+	})
+	j.wg.Wait()
+}
@@ -0,0 +1,57 @@
+package hw04lrucache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestJanitor(t *testing.T) {
+	t.Run("removes expired entries", func(t *testing.T) {
+		clock := newFakeClock()
+		c := NewGenericCache[string, int](10, WithClock(clock), WithJanitor(time.Second))
+		defer c.Close()
+
+		c.SetWithTTL("short", 1, time.Minute)
+		c.SetWithTTL("long", 2, time.Hour)
+		c.Set("forever", 3)
+
+		clock.Advance(time.Minute)
+		// Второй тик доставляется только после того, как обработан первый
+		clock.Tick()
+		clock.Tick()
+
+		internal := c.(*cache[string, int])
+		internal.mutex.Lock()
+		_, shortExists := internal.items["short"]
+		require.False(t, shortExists)
+		require.Len(t, internal.items, 2)
+		internal.mutex.Unlock()
+
+		val, ok := c.Get("long")
+		require.True(t, ok)
+		require.Equal(t, 2, val)
+	})
+
+	t.Run("close stops the goroutine", func(t *testing.T) {
+		clock := newFakeClock()
+		c := NewCache(10, WithClock(clock), WithJanitor(time.Second))
+
+		require.NoError(t, c.Close())
+		require.True(t, clock.ticker.Stopped())
+		// Повторный вызов безопасен
+		require.NoError(t, c.Close())
+
+		c.Set("k", 1)
+		_, ok := c.Get("k")
+		require.True(t, ok)
+	})
+
+	t.Run("no janitor by default", func(t *testing.T) {
+		clock := newFakeClock()
+		c := NewCache(10, WithClock(clock))
+		require.Nil(t, clock.ticker)
+		require.NoError(t, c.Close())
+	})
+}
@@ -0,0 +1,107 @@
+package hw04lrucache
+
+import (
+	"container/heap"
+	"iter"
+	"sort"
+)
+
+// lfuPolicy - вытеснение наименее часто используемого элемента; кучей упорядочены
+// частота обращений и, при равной частоте, время последнего обращения.
+type lfuPolicy[K comparable, V any] struct {
+	heap  lfuHeap[K, V]
+	ticks uint64
+}
+
+func newLFUPolicy[K comparable, V any]() *lfuPolicy[K, V] {
+	return &lfuPolicy[K, V]{}
+}
+
+func (p *lfuPolicy[K, V]) add(entry *cacheEntry[K, V]) {
+	p.ticks++
+	entry.freq, entry.tick = 1, p.ticks
+	heap.Push(&p.heap, entry)
+}
+
+func (p *lfuPolicy[K, V]) hit(entry *cacheEntry[K, V]) {
+	p.ticks++
+	entry.freq++
+	entry.tick = p.ticks
+	heap.Fix(&p.heap, entry.index)
+}
+
+func (p *lfuPolicy[K, V]) remove(entry *cacheEntry[K, V], _ EvictReason) {
+	heap.Remove(&p.heap, entry.index)
+}
+
+func (p *lfuPolicy[K, V]) victim(keep *cacheEntry[K, V]) *cacheEntry[K, V] {
+	if p.heap[0] != keep {
+		return p.heap[0]
+	}
+	// Следующий по порядку элемент кучи - меньший из потомков корня
+	switch {
+	case len(p.heap) > 2 && p.heap.Less(2, 1):
+		return p.heap[2]
+	case len(p.heap) > 1:
+		return p.heap[1]
+	}
+	return nil
+}
+
+func (p *lfuPolicy[K, V]) all() iter.Seq[*cacheEntry[K, V]] {
+	// Куча не упорядочена полностью, поэтому сортируем копию
+	entries := make(lfuHeap[K, V], len(p.heap))
+	copy(entries, p.heap)
+	sort.Slice(entries, func(i, j int) bool {
+		return entries.Less(j, i)
+	})
+
+	return func(yield func(*cacheEntry[K, V]) bool) {
+		for _, entry := range entries {
+			if !yield(entry) {
+				return
+			}
+		}
+	}
+}
+
+func (p *lfuPolicy[K, V]) reweigh(*cacheEntry[K, V], int64) {}
+
+func (p *lfuPolicy[K, V]) setCapacity(int64) {}
+
+func (p *lfuPolicy[K, V]) reset() {
+	p.heap = nil
+}
+
+type lfuHeap[K comparable, V any] []*cacheEntry[K, V]
+
+func (h lfuHeap[K, V]) Len() int {
+	return len(h)
+}
+
+func (h lfuHeap[K, V]) Less(i, j int) bool {
+	if h[i].freq != h[j].freq {
+		return h[i].freq < h[j].freq
+	}
+	return h[i].tick < h[j].tick
+}
+
+func (h lfuHeap[K, V]) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *lfuHeap[K, V]) Push(x any) {
+	entry := x.(*cacheEntry[K, V])
+	entry.index = len(*h)
+	*h = append(*h, entry)
+}
+
+func (h *lfuHeap[K, V]) Pop() any {
+	old := *h
+	entry := old[len(old)-1]
+	old[len(old)-1] = nil
+	*h = old[:len(old)-1]
+	return entry
+}
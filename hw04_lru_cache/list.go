@@ -2,148 +2,212 @@ package hw04lrucache
 
 import (
 	"fmt"
+	"iter"
 	"strings"
 )
 
-type List interface {
+// GenericList is a doubly linked list of values of type T.
+// Like container/list, methods that take an item do nothing if the item is nil,
+// belongs to another list or has already been removed.
+type GenericList[T any] interface {
 	Len() int
-	Front() *ListItem
-	Back() *ListItem
-	PushFront(v interface{}) *ListItem
-	PushBack(v interface{}) *ListItem
-	Remove(i *ListItem)
-	MoveToFront(i *ListItem)
+	Front() *GenericListItem[T]
+	Back() *GenericListItem[T]
+	PushFront(v T) *GenericListItem[T]
+	PushBack(v T) *GenericListItem[T]
+	// PushBackList appends copies of the values of other, which may be the list itself.
+	PushBackList(other GenericList[T])
+	// InsertBefore inserts v before mark and returns the new item, or nil if mark is not in the list.
+	InsertBefore(v T, mark *GenericListItem[T]) *GenericListItem[T]
+	// InsertAfter inserts v after mark and returns the new item, or nil if mark is not in the list.
+	InsertAfter(v T, mark *GenericListItem[T]) *GenericListItem[T]
+	Remove(i *GenericListItem[T])
+	MoveToFront(i *GenericListItem[T])
+	MoveToBack(i *GenericListItem[T])
+	// All iterates over the values from front to back.
+	All() iter.Seq[T]
+	// Backward iterates over the values from back to front.
+	Backward() iter.Seq[T]
 }
 
-type ListItem struct {
-	Value interface{}
-	Next  *ListItem
-	Prev  *ListItem
+// GenericListItem is an element of GenericList.
+type GenericListItem[T any] struct {
+	Value T
+	Next  *GenericListItem[T]
+	Prev  *GenericListItem[T]
+
+	list *linkedList[T] // Список, которому принадлежит элемент; nil после удаления
 }
 
-type linkedList struct {
+// List is the untyped list kept for compatibility with the original API.
+type List = GenericList[interface{}]
+
+// ListItem is an element of List.
+type ListItem = GenericListItem[interface{}]
+
+type linkedList[T any] struct {
 	Size      int
-	NodeFront *ListItem
-	NodeBack  *ListItem
+	NodeFront *GenericListItem[T]
+	NodeBack  *GenericListItem[T]
+}
+
+// NewGenericList returns an empty list of values of type T.
+func NewGenericList[T any]() GenericList[T] {
+	return &linkedList[T]{}
 }
 
+// NewList returns an empty untyped list.
 func NewList() List {
-	return &linkedList{}
+	return NewGenericList[interface{}]()
 }
 
-func (l *linkedList) Len() int {
+func (l *linkedList[T]) Len() int {
 	return l.Size
 }
 
-func (l *linkedList) Front() *ListItem {
+func (l *linkedList[T]) Front() *GenericListItem[T] {
 	return l.NodeFront
 }
 
-func (l *linkedList) Back() *ListItem {
+func (l *linkedList[T]) Back() *GenericListItem[T] {
 	return l.NodeBack
 }
 
-func (l *linkedList) PushFront(v interface{}) *ListItem {
-	// Создаем listItem со значением, но без привязки
-	listItem := ListItem{Value: v, Next: nil, Prev: nil}
-
-	// Если размер связанного списка = 0
-	if l.Size == 0 {
-		l.NodeFront = &listItem
-		l.NodeBack = &listItem
-	} else {
-		listItem.Next = l.NodeFront
-		l.NodeFront.Prev = &listItem
-		l.NodeFront = &listItem
-	}
-
-	l.Size++
-
-	return &listItem
+func (l *linkedList[T]) PushFront(v T) *GenericListItem[T] {
+	return l.insert(v, nil, l.NodeFront)
 }
 
-func (l *linkedList) PushBack(v interface{}) *ListItem {
-	// Создаем listItem со значением, но без привязки
-	listItem := ListItem{Value: v, Next: nil, Prev: nil}
+func (l *linkedList[T]) PushBack(v T) *GenericListItem[T] {
+	return l.insert(v, l.NodeBack, nil)
+}
 
-	// Если размер связанного списка = 0
-	if l.Size == 0 {
-		l.NodeFront = &listItem
-		l.NodeBack = &listItem
-		l.Size++
-		return &listItem
+func (l *linkedList[T]) PushBackList(other GenericList[T]) {
+	// Длину запоминаем заранее, чтобы список, добавляемый сам к себе, не рос бесконечно
+	i := other.Front()
+	for n := other.Len(); n > 0; n-- {
+		l.PushBack(i.Value)
+		i = i.Next
 	}
-
-	// К listItem.Prev привязываем первый элемент списка linkedList.NodeBack
-	listItem.Prev = l.NodeBack
-	l.NodeBack.Next = &listItem
-	l.NodeBack = &listItem
-	l.Size++
-	return &listItem
 }
 
-func (l *linkedList) Remove(i *ListItem) {
-	// Если пустой параметр или список пустой - ничего не делаем
-	if i == nil || l.Size == 0 {
-		return
+func (l *linkedList[T]) InsertBefore(v T, mark *GenericListItem[T]) *GenericListItem[T] {
+	if !l.owns(mark) {
+		return nil
 	}
+	return l.insert(v, mark.Prev, mark)
+}
 
-	// Обновляем связи соседних элементов
-	if i.Prev != nil {
-		i.Prev.Next = i.Next
-	}
-	if i.Next != nil {
-		i.Next.Prev = i.Prev
+func (l *linkedList[T]) InsertAfter(v T, mark *GenericListItem[T]) *GenericListItem[T] {
+	if !l.owns(mark) {
+		return nil
 	}
+	return l.insert(v, mark, mark.Next)
+}
 
-	// Обновляем границы списка
-	if i == l.NodeFront {
-		l.NodeFront = i.Next
-	}
-	if i == l.NodeBack {
-		l.NodeBack = i.Prev
+func (l *linkedList[T]) Remove(i *GenericListItem[T]) {
+	// Чужой или уже удаленный элемент не трогаем, иначе испортим длину и границы списка
+	if !l.owns(i) {
+		return
 	}
 
+	l.unlink(i)
+
 	// Очищаем связи удаляемого элемента
 	i.Next = nil
 	i.Prev = nil
+	i.list = nil
+}
 
-	// Уменьшаем длину списка в отложенном вызове
-	l.Size--
+func (l *linkedList[T]) MoveToFront(i *GenericListItem[T]) {
+	// Если элемент чужой или уже занимает front-позицию - ничего не делаем
+	if !l.owns(i) || i == l.NodeFront {
+		return
+	}
+	l.unlink(i)
+	l.link(i, nil, l.NodeFront)
 }
 
-func (l *linkedList) MoveToFront(i *ListItem) {
-	// Если пустой параметр, или элемент уже занимает front-позицию
-	// или список длиной <= 1 - ничего не делаем
-	if i == nil || i == l.NodeFront || l.Size <= 1 {
+func (l *linkedList[T]) MoveToBack(i *GenericListItem[T]) {
+	if !l.owns(i) || i == l.NodeBack {
 		return
 	}
+	l.unlink(i)
+	l.link(i, l.NodeBack, nil)
+}
 
-	// Сохраняем оригинальные указатели
-	prev := i.Prev
-	next := i.Next
+func (l *linkedList[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := l.NodeFront; i != nil; i = i.Next {
+			if !yield(i.Value) {
+				return
+			}
+		}
+	}
+}
 
-	// Обновляем соседние элементы
+func (l *linkedList[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := l.NodeBack; i != nil; i = i.Prev {
+			if !yield(i.Value) {
+				return
+			}
+		}
+	}
+}
+
+// owns сообщает, принадлежит ли элемент этому списку.
+func (l *linkedList[T]) owns(i *GenericListItem[T]) bool {
+	return i != nil && i.list == l
+}
+
+// insert создает элемент со значением v между prev и next.
+func (l *linkedList[T]) insert(v T, prev, next *GenericListItem[T]) *GenericListItem[T] {
+	listItem := &GenericListItem[T]{Value: v, list: l}
+	l.link(listItem, prev, next)
+	return listItem
+}
+
+// link вставляет элемент между соседними prev и next; nil означает границу списка.
+func (l *linkedList[T]) link(i, prev, next *GenericListItem[T]) {
+	i.Prev, i.Next = prev, next
+
+	// Обновляем соседние элементы или границы списка
 	if prev != nil {
-		prev.Next = next
+		prev.Next = i
+	} else {
+		l.NodeFront = i
 	}
 	if next != nil {
-		next.Prev = prev
+		next.Prev = i
+	} else {
+		l.NodeBack = i
 	}
 
-	// Обновляем NodeBack, если перемещаемый элемент был последним
+	l.Size++
+}
+
+// unlink исключает элемент из цепочки, не очищая его собственные связи.
+func (l *linkedList[T]) unlink(i *GenericListItem[T]) {
+	// Обновляем связи соседних элементов
+	if i.Prev != nil {
+		i.Prev.Next = i.Next
+	}
+	if i.Next != nil {
+		i.Next.Prev = i.Prev
+	}
+
+	// Обновляем границы списка
+	if i == l.NodeFront {
+		l.NodeFront = i.Next
+	}
 	if i == l.NodeBack {
-		l.NodeBack = prev
+		l.NodeBack = i.Prev
 	}
 
-	// Вставляем в начало
-	i.Prev = nil
-	i.Next = l.NodeFront
-	l.NodeFront.Prev = i
-	l.NodeFront = i
+	l.Size--
 }
 
-func (l *linkedList) String() string {
+func (l *linkedList[T]) String() string {
 	if l.NodeFront == nil {
 		return "nil"
 	}
@@ -2,6 +2,7 @@ package hw04lrucache
 
 import (
 	"fmt"
+	"slices"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -65,4 +66,94 @@ func TestList(t *testing.T) {
 		require.Equal(t, list.Len(), 4)
 		require.Equal(t, fmt.Sprint(list), "123 ↔ C ↔ 1 ↔ B")
 	})
+	t.Run("generic values", func(t *testing.T) {
+		l := NewGenericList[string]()
+		l.PushBack("b")
+		l.PushFront("a")
+		last := l.PushBack("c")
+		l.MoveToFront(last)
+
+		elems := make([]string, 0, l.Len())
+		for i := l.Front(); i != nil; i = i.Next {
+			elems = append(elems, i.Value)
+		}
+		require.Equal(t, []string{"c", "a", "b"}, elems)
+		require.Equal(t, "c ↔ a ↔ b", fmt.Sprint(l))
+	})
+	t.Run("insert and move", func(t *testing.T) {
+		l := NewGenericList[int]()
+		two := l.PushBack(2)          // [2]
+		l.InsertBefore(1, two)        // [1, 2]
+		four := l.InsertAfter(4, two) // [1, 2, 4]
+		l.InsertAfter(3, two)         // [1, 2, 3, 4]
+		l.InsertBefore(0, l.Front())  // [0, 1, 2, 3, 4]
+		require.Equal(t, 4, l.Back().Value)
+
+		l.MoveToBack(two)      // [0, 1, 3, 4, 2]
+		l.MoveToBack(l.Back()) // [0, 1, 3, 4, 2]
+		l.MoveToFront(four)    // [4, 0, 1, 3, 2]
+
+		require.Equal(t, 5, l.Len())
+		require.Equal(t, []int{4, 0, 1, 3, 2}, slices.Collect(l.All()))
+		require.Equal(t, []int{2, 3, 1, 0, 4}, slices.Collect(l.Backward()))
+		require.Equal(t, "4 ↔ 0 ↔ 1 ↔ 3 ↔ 2", fmt.Sprint(l))
+	})
+
+	t.Run("push back list", func(t *testing.T) {
+		l := NewGenericList[string]()
+		l.PushBack("a")
+		other := NewGenericList[string]()
+		other.PushBack("b")
+		other.PushBack("c")
+
+		l.PushBackList(other)
+		require.Equal(t, []string{"a", "b", "c"}, slices.Collect(l.All()))
+		require.Equal(t, 2, other.Len())
+
+		l.PushBackList(l)
+		require.Equal(t, []string{"a", "b", "c", "a", "b", "c"}, slices.Collect(l.All()))
+		require.Equal(t, 6, l.Len())
+	})
+
+	t.Run("iteration stops early", func(t *testing.T) {
+		l := NewGenericList[int]()
+		for i := range 5 {
+			l.PushBack(i)
+		}
+
+		var seen []int
+		for v := range l.Backward() {
+			if v < 3 {
+				break
+			}
+			seen = append(seen, v)
+		}
+		require.Equal(t, []int{4, 3}, seen)
+	})
+
+	t.Run("foreign and removed items are ignored", func(t *testing.T) {
+		l := NewGenericList[int]()
+		l.PushBack(1)
+		l.PushBack(2)
+		other := NewGenericList[int]()
+		foreign := other.PushBack(3)
+
+		l.Remove(foreign)
+		l.MoveToFront(foreign)
+		l.MoveToBack(foreign)
+		require.Nil(t, l.InsertBefore(4, foreign))
+		require.Nil(t, l.InsertAfter(4, foreign))
+		require.Nil(t, l.InsertAfter(4, nil))
+		require.Equal(t, []int{1, 2}, slices.Collect(l.All()))
+		require.Equal(t, []int{3}, slices.Collect(other.All()))
+
+		removed := l.Front()
+		l.Remove(removed)
+		l.Remove(removed)
+		l.MoveToFront(removed)
+		require.Equal(t, 1, l.Len())
+		require.Equal(t, []int{2}, slices.Collect(l.All()))
+		require.Nil(t, removed.Next)
+		require.Nil(t, removed.Prev)
+	})
 }
@@ -0,0 +1,142 @@
+package hw04lrucache
+
+import (
+	"context"
+	"time"
+)
+
+// Loader computes the value for a key missing from the cache.
+type Loader[K comparable, V any] func(ctx context.Context, key K) (V, error)
+
+// loadCall - выполняющаяся загрузка значения, которую ждут все запросившие ключ.
+type loadCall[V any] struct {
+	done    chan struct{}
+	value   V
+	err     error
+	waiters int
+	cancel  context.CancelFunc
+	// Ключ записали или удалили во время загрузки, и ее результат устарел
+	superseded bool
+}
+
+// loadFailure - ошибка загрузки, сохраненная на время WithNegativeTTL.
+type loadFailure struct {
+	err       error
+	expiresAt time.Time
+}
+
+func (c *cache[K, V]) GetOrLoad(ctx context.Context, key K, loader Loader[K, V]) (V, error) {
+	// Поиск в кэше и проверка выполняющихся загрузок идут под одной блокировкой: иначе
+	// загрузка могла бы завершиться между ними, и ключ загрузился бы повторно
+	c.mutex.Lock()
+	if value, ok := c.get(key); ok {
+		c.unlock()
+		return value, nil
+	}
+	if err := ctx.Err(); err != nil {
+		c.unlock()
+		var zero V
+		return zero, err
+	}
+	if err := c.recentFailure(key); err != nil {
+		c.unlock()
+		var zero V
+		return zero, err
+	}
+	call, loading := c.loads[key]
+	if loading {
+		call.waiters++
+	} else {
+		call = c.startLoad(ctx, key, loader)
+	}
+	c.unlock()
+
+	select {
+	case <-call.done:
+		return call.value, call.err
+	case <-ctx.Done():
+		// Загрузку отменяем, только если ее больше никто не ждет. Отмененную загрузку
+		// сразу убираем из словаря, чтобы следующий вызов начал новую, а не получил ее ошибку
+		c.mutex.Lock()
+		call.waiters--
+		if call.waiters == 0 {
+			call.cancel()
+			c.forgetLoad(key, call)
+		}
+		c.mutex.Unlock()
+
+		var zero V
+		return zero, ctx.Err()
+	}
+}
+
+// startLoad запускает загрузку в отдельной горутине, чтобы отмена контекста первого
+// вызывающего не прерывала ее для остальных. Вызывается под блокировкой.
+func (c *cache[K, V]) startLoad(ctx context.Context, key K, loader Loader[K, V]) *loadCall[V] {
+	loadCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	call := &loadCall[V]{done: make(chan struct{}), waiters: 1, cancel: cancel}
+	c.loads[key] = call
+
+	go func() {
+		defer cancel()
+		call.value, call.err = loader(loadCtx, key)
+
+		var expiresAt time.Time
+		if c.defaultTTL > 0 {
+			expiresAt = c.clock.Now().Add(c.defaultTTL)
+		}
+		var weight int64
+		if call.err == nil {
+			weight = c.weigh(key, call.value)
+		}
+
+		c.mutex.Lock()
+		// Результат отмененной загрузки не сохраняем: ключ уже могла загрузить новая.
+		// Результат загрузки, во время которой ключ записали или удалили, устарел.
+		// Ошибку таких загрузок тоже не запоминаем
+		if loadCtx.Err() == nil && !call.superseded {
+			switch {
+			case call.err == nil:
+				// Значение кладем в кэш до завершения загрузки, чтобы следующий вызов не начал
+				// новую. Значение тяжелее всего кэша не сохраняется, но возвращается ждущим
+				_, _ = c.set(key, call.value, expiresAt, weight)
+			case c.negativeTTL > 0:
+				c.failures[key] = loadFailure{err: call.err, expiresAt: c.clock.Now().Add(c.negativeTTL)}
+			}
+		}
+		c.forgetLoad(key, call)
+		c.unlock()
+
+		close(call.done)
+	}()
+	return call
+}
+
+// supersedeLoad отмечает, что результат выполняющейся загрузки ключа устарел.
+// Вызывается под блокировкой.
+func (c *cache[K, V]) supersedeLoad(key K) {
+	if call, ok := c.loads[key]; ok {
+		call.superseded = true
+	}
+}
+
+// forgetLoad убирает загрузку из словаря, если ее еще не заменила новая.
+// Вызывается под блокировкой.
+func (c *cache[K, V]) forgetLoad(key K, call *loadCall[V]) {
+	if c.loads[key] == call {
+		delete(c.loads, key)
+	}
+}
+
+// recentFailure возвращает сохраненную ошибку загрузки ключа. Вызывается под блокировкой.
+func (c *cache[K, V]) recentFailure(key K) error {
+	failure, ok := c.failures[key]
+	if !ok {
+		return nil
+	}
+	if !c.clock.Now().Before(failure.expiresAt) {
+		delete(c.failures, key)
+		return nil
+	}
+	return failure.err
+}
@@ -0,0 +1,306 @@
+package hw04lrucache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+var errLoad = errors.New("load failed")
+
+// loadWaiters возвращает число вызовов, ожидающих загрузку ключа.
+func loadWaiters(c GenericCache[string, int], key string) int {
+	internal := c.(*cache[string, int])
+	internal.mutex.Lock()
+	defer internal.mutex.Unlock()
+	if call, ok := internal.loads[key]; ok {
+		return call.waiters
+	}
+	return 0
+}
+
+func TestGetOrLoad(t *testing.T) {
+	t.Run("caches loaded value", func(t *testing.T) {
+		c := NewGenericCache[string, int](5)
+		var calls int
+		loader := func(_ context.Context, key string) (int, error) {
+			calls++
+			return len(key), nil
+		}
+
+		value, err := c.GetOrLoad(context.Background(), "abc", loader)
+		require.NoError(t, err)
+		require.Equal(t, 3, value)
+
+		value, err = c.GetOrLoad(context.Background(), "abc", loader)
+		require.NoError(t, err)
+		require.Equal(t, 3, value)
+		require.Equal(t, 1, calls)
+
+		value, ok := c.Get("abc")
+		require.True(t, ok)
+		require.Equal(t, 3, value)
+	})
+
+	t.Run("concurrent callers share one load", func(t *testing.T) {
+		const callers = 10
+		c := NewGenericCache[string, int](5)
+		release := make(chan struct{})
+		var calls atomic.Int32
+		loader := func(context.Context, string) (int, error) {
+			calls.Add(1)
+			<-release
+			return 42, nil
+		}
+
+		var wg sync.WaitGroup
+		results := make([]int, callers)
+		errs := make([]error, callers)
+		for i := range callers {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				results[i], errs[i] = c.GetOrLoad(context.Background(), "key", loader)
+			}()
+		}
+		require.Eventually(t, func() bool {
+			return loadWaiters(c, "key") == callers
+		}, time.Second, time.Millisecond)
+		close(release)
+		wg.Wait()
+
+		require.Equal(t, int32(1), calls.Load())
+		for i, value := range results {
+			require.NoError(t, errs[i])
+			require.Equal(t, 42, value)
+		}
+	})
+
+	t.Run("loader errors are shared and not cached by default", func(t *testing.T) {
+		c := NewGenericCache[string, int](5)
+		var calls int
+		loader := func(context.Context, string) (int, error) {
+			calls++
+			return 0, errLoad
+		}
+
+		_, err := c.GetOrLoad(context.Background(), "key", loader)
+		require.ErrorIs(t, err, errLoad)
+		_, err = c.GetOrLoad(context.Background(), "key", loader)
+		require.ErrorIs(t, err, errLoad)
+		require.Equal(t, 2, calls)
+		require.Zero(t, c.Len())
+	})
+
+	t.Run("done context is returned before loading", func(t *testing.T) {
+		c := NewGenericCache[string, int](5)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := c.GetOrLoad(ctx, "key", func(context.Context, string) (int, error) {
+			t.Fatal("loader must not be called")
+			return 0, nil
+		})
+		require.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("last waiter leaving cancels the load", func(t *testing.T) {
+		c := NewGenericCache[string, int](5)
+		ctx, cancel := context.WithCancel(context.Background())
+		started, stopped := make(chan struct{}), make(chan struct{})
+		loader := func(ctx context.Context, _ string) (int, error) {
+			close(started)
+			<-ctx.Done()
+			close(stopped)
+			return 0, ctx.Err()
+		}
+
+		go func() {
+			<-started
+			cancel()
+		}()
+		_, err := c.GetOrLoad(ctx, "key", loader)
+		require.ErrorIs(t, err, context.Canceled)
+		<-stopped
+
+		_, ok := c.Get("key")
+		require.False(t, ok)
+	})
+
+	t.Run("caller after cancelled load starts a new one", func(t *testing.T) {
+		c := NewGenericCache[string, int](5)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancelled, finish, stopped := make(chan struct{}), make(chan struct{}), make(chan struct{})
+		// Отмененная загрузка завершается только по сигналу, чтобы второй вызов застал ее
+		slowLoader := func(ctx context.Context, _ string) (int, error) {
+			defer close(stopped)
+			<-ctx.Done()
+			close(cancelled)
+			<-finish
+			return 0, ctx.Err()
+		}
+
+		firstErr := make(chan error)
+		go func() {
+			_, err := c.GetOrLoad(ctx, "key", slowLoader)
+			firstErr <- err
+		}()
+		require.Eventually(t, func() bool {
+			return loadWaiters(c, "key") == 1
+		}, time.Second, time.Millisecond)
+		cancel()
+		require.ErrorIs(t, <-firstErr, context.Canceled)
+		<-cancelled
+
+		// Присоединение к отмененной загрузке зависло бы до ее завершения
+		second := make(chan error)
+		go func() {
+			value, err := c.GetOrLoad(context.Background(), "key", func(context.Context, string) (int, error) {
+				return 42, nil
+			})
+			if err == nil && value != 42 {
+				err = fmt.Errorf("unexpected value %d", value)
+			}
+			second <- err
+		}()
+		select {
+		case err := <-second:
+			require.NoError(t, err)
+		case <-time.After(time.Second):
+			t.Fatal("second caller joined the cancelled load")
+		}
+
+		close(finish)
+		<-stopped
+		value, ok := c.Get("key")
+		require.True(t, ok)
+		require.Equal(t, 42, value)
+	})
+
+	t.Run("load continues while someone waits", func(t *testing.T) {
+		c := NewGenericCache[string, int](5)
+		release := make(chan struct{})
+		loader := func(ctx context.Context, _ string) (int, error) {
+			select {
+			case <-release:
+				return 7, nil
+			case <-ctx.Done():
+				return 0, ctx.Err()
+			}
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		firstErr := make(chan error)
+		go func() {
+			_, err := c.GetOrLoad(ctx, "key", loader)
+			firstErr <- err
+		}()
+		require.Eventually(t, func() bool {
+			return loadWaiters(c, "key") == 1
+		}, time.Second, time.Millisecond)
+
+		var secondValue int
+		secondErr := make(chan error)
+		go func() {
+			var err error
+			secondValue, err = c.GetOrLoad(context.Background(), "key", loader)
+			secondErr <- err
+		}()
+		require.Eventually(t, func() bool {
+			return loadWaiters(c, "key") == 2
+		}, time.Second, time.Millisecond)
+
+		cancel()
+		require.ErrorIs(t, <-firstErr, context.Canceled)
+		close(release)
+		require.NoError(t, <-secondErr)
+		require.Equal(t, 7, secondValue)
+	})
+
+	t.Run("write during load wins over loaded value", func(t *testing.T) {
+		for _, write := range []struct {
+			name  string
+			apply func(c GenericCache[string, int])
+			value int
+			ok    bool
+		}{
+			{name: "set", apply: func(c GenericCache[string, int]) { c.Set("key", 1) }, value: 1, ok: true},
+			{name: "delete", apply: func(c GenericCache[string, int]) { c.Delete("key") }},
+			{name: "clear", apply: func(c GenericCache[string, int]) { c.Clear() }},
+		} {
+			t.Run(write.name, func(t *testing.T) {
+				c := NewGenericCache[string, int](5)
+				release := make(chan struct{})
+				loader := func(context.Context, string) (int, error) {
+					<-release
+					return 7, nil
+				}
+
+				var loaded int
+				loadErr := make(chan error)
+				go func() {
+					var err error
+					loaded, err = c.GetOrLoad(context.Background(), "key", loader)
+					loadErr <- err
+				}()
+				require.Eventually(t, func() bool {
+					return loadWaiters(c, "key") == 1
+				}, time.Second, time.Millisecond)
+
+				write.apply(c)
+				close(release)
+				require.NoError(t, <-loadErr)
+				// Ждущие получают загруженное значение, но в кэше остается записанное
+				require.Equal(t, 7, loaded)
+				value, ok := c.Peek("key")
+				require.Equal(t, write.ok, ok)
+				require.Equal(t, write.value, value)
+			})
+		}
+	})
+
+	t.Run("negative ttl", func(t *testing.T) {
+		clock := newFakeClock()
+		c := NewGenericCache[string, int](5, WithClock(clock), WithNegativeTTL(time.Minute))
+		var calls int
+		loader := func(context.Context, string) (int, error) {
+			calls++
+			return 0, errLoad
+		}
+
+		_, err := c.GetOrLoad(context.Background(), "key", loader)
+		require.ErrorIs(t, err, errLoad)
+		_, err = c.GetOrLoad(context.Background(), "key", loader)
+		require.ErrorIs(t, err, errLoad)
+		require.Equal(t, 1, calls)
+
+		clock.Advance(time.Minute)
+		_, err = c.GetOrLoad(context.Background(), "key", loader)
+		require.ErrorIs(t, err, errLoad)
+		require.Equal(t, 2, calls)
+
+		// Удаление ключа забывает ошибку
+		c.Delete("key")
+		value, err := c.GetOrLoad(context.Background(), "key", func(context.Context, string) (int, error) {
+			return 1, nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, 1, value)
+	})
+
+	t.Run("sharded cache", func(t *testing.T) {
+		c := NewGenericShardedCache[string, int](4, 2, func(key string) uint64 { return uint64(len(key)) })
+		value, err := c.GetOrLoad(context.Background(), "ab", func(_ context.Context, key string) (int, error) {
+			return len(key), nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, 2, value)
+		require.Equal(t, 1, c.Len())
+	})
+}
@@ -0,0 +1,74 @@
+package hw04lrucache
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// StatsSource reports cache statistics. Every Cache and GenericCache is a StatsSource.
+type StatsSource interface {
+	Stats() Stats
+}
+
+// metric - описание счетчика для текстового формата Prometheus.
+type metric struct {
+	name  string
+	help  string
+	value func(Stats) uint64
+}
+
+var metrics = []metric{
+	{
+		name:  "lru_cache_hits_total",
+		help:  "Number of Get calls that found a live entry.",
+		value: func(s Stats) uint64 { return s.Hits },
+	},
+	{
+		name:  "lru_cache_misses_total",
+		help:  "Number of Get calls that found no live entry.",
+		value: func(s Stats) uint64 { return s.Misses },
+	},
+	{
+		name:  "lru_cache_evictions_total",
+		help:  "Number of entries evicted by capacity or expiration.",
+		value: func(s Stats) uint64 { return s.Evictions },
+	},
+	{
+		name:  "lru_cache_sets_total",
+		help:  "Number of entries added to the cache.",
+		value: func(s Stats) uint64 { return s.Sets },
+	},
+	{
+		name:  "lru_cache_updates_total",
+		help:  "Number of values replaced in the cache.",
+		value: func(s Stats) uint64 { return s.Updates },
+	},
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// WriteMetrics writes the counters of caches in the Prometheus text exposition format.
+// Each cache is labelled with its key in caches, e.g. lru_cache_hits_total{cache="users"}.
+func WriteMetrics(w io.Writer, caches map[string]StatsSource) error {
+	names := slices.Sorted(maps.Keys(caches))
+
+	// Снимок берем один раз, чтобы все метрики кэша были согласованы между собой
+	stats := make([]Stats, len(names))
+	for i, name := range names {
+		stats[i] = caches[name].Stats()
+	}
+
+	// Формат требует, чтобы все значения одной метрики шли подряд после ее HELP и TYPE
+	bw := bufio.NewWriter(w)
+	for _, m := range metrics {
+		fmt.Fprintf(bw, "# HELP %s %s\n# TYPE %s counter\n", m.name, m.help, m.name)
+		for i, name := range names {
+			fmt.Fprintf(bw, "%s{cache=\"%s\"} %d\n", m.name, labelEscaper.Replace(name), m.value(stats[i]))
+		}
+	}
+	return bw.Flush()
+}
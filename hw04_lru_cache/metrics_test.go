@@ -0,0 +1,45 @@
+package hw04lrucache
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteMetrics(t *testing.T) {
+	users := NewCache(2)
+	users.Set("a", 1)
+	users.Get("a")
+	users.Get("b")
+	sessions := NewGenericCache[int, string](1)
+	sessions.Set(1, "x")
+	sessions.Set(2, "y")
+
+	var sb strings.Builder
+	err := WriteMetrics(&sb, map[string]StatsSource{"users": users, `se"ss`: sessions})
+	require.NoError(t, err)
+
+	expected := `# HELP lru_cache_hits_total Number of Get calls that found a live entry.
+# TYPE lru_cache_hits_total counter
+lru_cache_hits_total{cache="se\"ss"} 0
+lru_cache_hits_total{cache="users"} 1
+# HELP lru_cache_misses_total Number of Get calls that found no live entry.
+# TYPE lru_cache_misses_total counter
+lru_cache_misses_total{cache="se\"ss"} 0
+lru_cache_misses_total{cache="users"} 1
+# HELP lru_cache_evictions_total Number of entries evicted by capacity or expiration.
+# TYPE lru_cache_evictions_total counter
+lru_cache_evictions_total{cache="se\"ss"} 1
+lru_cache_evictions_total{cache="users"} 0
+# HELP lru_cache_sets_total Number of entries added to the cache.
+# TYPE lru_cache_sets_total counter
+lru_cache_sets_total{cache="se\"ss"} 2
+lru_cache_sets_total{cache="users"} 1
+# HELP lru_cache_updates_total Number of values replaced in the cache.
+# TYPE lru_cache_updates_total counter
+lru_cache_updates_total{cache="se\"ss"} 0
+lru_cache_updates_total{cache="users"} 0
+`
+	require.Equal(t, expected, sb.String())
+}
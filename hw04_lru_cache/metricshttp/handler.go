@@ -0,0 +1,17 @@
+package metricshttp
+
+import (
+	"net/http"
+
+	hw04 "github.com/j85529016-prog/GoProf_01/hw04_lru_cache"
+)
+
+// Handler serves the counters of caches in the Prometheus text exposition format,
+// so it can be mounted at /metrics. It lives apart from the cache package, so that
+// importing the cache does not pull in net/http.
+func Handler(caches map[string]hw04.StatsSource) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		_ = hw04.WriteMetrics(w, caches)
+	})
+}
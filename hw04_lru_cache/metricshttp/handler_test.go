@@ -0,0 +1,23 @@
+package metricshttp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	hw04 "github.com/j85529016-prog/GoProf_01/hw04_lru_cache"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler(t *testing.T) {
+	c := hw04.NewCache(1)
+	c.Get("a")
+
+	recorder := httptest.NewRecorder()
+	Handler(map[string]hw04.StatsSource{"main": c}).
+		ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Contains(t, recorder.Header().Get("Content-Type"), "version=0.0.4")
+	require.Contains(t, recorder.Body.String(), `lru_cache_misses_total{cache="main"} 1`+"\n")
+}
@@ -0,0 +1,68 @@
+package hw04lrucache
+
+import "time"
+
+// Option configures a cache created by NewCache or NewGenericCache.
+type Option func(*options)
+
+type options struct {
+	defaultTTL      time.Duration
+	clock           Clock
+	janitorInterval time.Duration
+	policy          Policy
+	negativeTTL     time.Duration
+	codec           Codec
+}
+
+func newOptions(opts []Option) options {
+	o := options{clock: systemClock{}, codec: GobCodec}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// WithDefaultTTL sets the lifetime of entries added by Set. Non-positive ttl means entries never expire.
+func WithDefaultTTL(ttl time.Duration) Option {
+	return func(o *options) {
+		o.defaultTTL = ttl
+	}
+}
+
+// WithClock replaces the system clock used to expire entries.
+func WithClock(clock Clock) Option {
+	return func(o *options) {
+		o.clock = clock
+	}
+}
+
+// WithJanitor starts a background goroutine that removes expired entries every interval.
+// The goroutine runs until Close is called. Without a janitor expired entries are removed
+// lazily when they are accessed or evicted by capacity.
+func WithJanitor(interval time.Duration) Option {
+	return func(o *options) {
+		o.janitorInterval = interval
+	}
+}
+
+// WithPolicy selects the eviction policy, PolicyLRU by default.
+func WithPolicy(p Policy) Option {
+	return func(o *options) {
+		o.policy = p
+	}
+}
+
+// WithNegativeTTL makes GetOrLoad remember loader errors for ttl and return them
+// without calling the loader again. Errors are not remembered by default.
+func WithNegativeTTL(ttl time.Duration) Option {
+	return func(o *options) {
+		o.negativeTTL = ttl
+	}
+}
+
+// WithCodec sets the format of Snapshot and Restore. The default is GobCodec.
+func WithCodec(codec Codec) Option {
+	return func(o *options) {
+		o.codec = codec
+	}
+}
@@ -0,0 +1,145 @@
+package hw04lrucache
+
+import "iter"
+
+// Policy selects the order in which a full cache evicts entries.
+type Policy int
+
+const (
+	// PolicyLRU evicts the least recently used entry.
+	PolicyLRU Policy = iota
+	// PolicyLFU evicts the least frequently used entry, the least recently used one among equals.
+	PolicyLFU
+	// Policy2Q keeps entries seen once in a FIFO queue and promotes them to the LRU queue
+	// only if they are requested again soon after eviction, so one-time scans do not flush
+	// frequently used entries.
+	Policy2Q
+	// PolicyTinyLFU is W-TinyLFU: new entries enter a small LRU window and are admitted
+	// to the main segmented LRU only if their estimated frequency beats the entry to be evicted.
+	PolicyTinyLFU
+)
+
+func (p Policy) String() string {
+	switch p {
+	case PolicyLRU:
+		return "LRU"
+	case PolicyLFU:
+		return "LFU"
+	case Policy2Q:
+		return "2Q"
+	case PolicyTinyLFU:
+		return "W-TinyLFU"
+	}
+	return "unknown"
+}
+
+// policy - стратегия вытеснения: хранит порядок элементов кэша и выбирает жертву.
+// Вызывается под блокировкой кэша.
+type policy[K comparable, V any] interface {
+	// add регистрирует новый элемент.
+	add(entry *cacheEntry[K, V])
+	// hit отмечает обращение к элементу.
+	hit(entry *cacheEntry[K, V])
+	// remove исключает элемент по указанной причине.
+	remove(entry *cacheEntry[K, V], reason EvictReason)
+	// victim выбирает элемент для вытеснения, отличный от keep. Кэш вызывает его,
+	// только если кроме keep есть что вытеснять; keep может быть nil.
+	victim(keep *cacheEntry[K, V]) *cacheEntry[K, V]
+	// all перечисляет элементы от наиболее к наименее ценному.
+	all() iter.Seq[*cacheEntry[K, V]]
+	// reweigh сообщает об изменении веса элемента.
+	reweigh(entry *cacheEntry[K, V], oldWeight int64)
+	// setCapacity сообщает новую емкость кэша в единицах веса.
+	setCapacity(capacity int64)
+	// reset удаляет все элементы.
+	reset()
+}
+
+// segment - список политики, в котором находится элемент.
+type segment uint8
+
+const (
+	segmentMain segment = iota
+	segmentRecent
+	segmentWindow
+	segmentProbation
+	segmentProtected
+)
+
+func newPolicy[K comparable, V any](kind Policy, capacity int64) policy[K, V] {
+	switch kind {
+	case PolicyLFU:
+		return newLFUPolicy[K, V]()
+	case Policy2Q:
+		return newTwoQueuePolicy[K, V](capacity)
+	case PolicyTinyLFU:
+		return newTinyLFUPolicy[K, V](capacity)
+	case PolicyLRU:
+	}
+	return newLRUPolicy[K, V]()
+}
+
+// lruPolicy - очередь последних используемых элементов на основе двусвязного списка.
+type lruPolicy[K comparable, V any] struct {
+	queue GenericList[*cacheEntry[K, V]]
+}
+
+func newLRUPolicy[K comparable, V any]() *lruPolicy[K, V] {
+	return &lruPolicy[K, V]{queue: NewGenericList[*cacheEntry[K, V]]()}
+}
+
+func (p *lruPolicy[K, V]) add(entry *cacheEntry[K, V]) {
+	entry.node = p.queue.PushFront(entry)
+}
+
+func (p *lruPolicy[K, V]) hit(entry *cacheEntry[K, V]) {
+	p.queue.MoveToFront(entry.node)
+}
+
+func (p *lruPolicy[K, V]) remove(entry *cacheEntry[K, V], _ EvictReason) {
+	p.queue.Remove(entry.node)
+}
+
+func (p *lruPolicy[K, V]) victim(keep *cacheEntry[K, V]) *cacheEntry[K, V] {
+	return backExcept(p.queue, keep)
+}
+
+func (p *lruPolicy[K, V]) all() iter.Seq[*cacheEntry[K, V]] {
+	return listEntries(p.queue)
+}
+
+func (p *lruPolicy[K, V]) reweigh(*cacheEntry[K, V], int64) {}
+
+func (p *lruPolicy[K, V]) setCapacity(int64) {}
+
+func (p *lruPolicy[K, V]) reset() {
+	p.queue = NewGenericList[*cacheEntry[K, V]]()
+}
+
+// backExcept возвращает последний элемент списка, пропуская keep, или nil.
+func backExcept[K comparable, V any](l GenericList[*cacheEntry[K, V]], keep *cacheEntry[K, V]) *cacheEntry[K, V] {
+	item := l.Back()
+	if item != nil && item.Value == keep {
+		item = item.Prev
+	}
+	if item == nil {
+		return nil
+	}
+	return item.Value
+}
+
+// listEntries перечисляет элементы списков по порядку, от начала к концу каждого списка.
+func listEntries[K comparable, V any](lists ...GenericList[*cacheEntry[K, V]]) iter.Seq[*cacheEntry[K, V]] {
+	return func(yield func(*cacheEntry[K, V]) bool) {
+		for _, l := range lists {
+			for item := l.Front(); item != nil; {
+				// Запоминаем следующий элемент заранее: текущий могут удалить во время обхода
+				next := item.Next
+				if !yield(item.Value) {
+					return
+				}
+				item = next
+			}
+		}
+	}
+}
@@ -0,0 +1,229 @@
+package hw04lrucache
+
+import (
+	"bufio"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var allPolicies = []Policy{PolicyLRU, PolicyLFU, Policy2Q, PolicyTinyLFU}
+
+func TestPolicies(t *testing.T) {
+	for _, p := range allPolicies {
+		t.Run(p.String(), func(t *testing.T) {
+			t.Run("basic operations", func(t *testing.T) {
+				c := NewCache(3, WithPolicy(p))
+
+				require.False(t, c.Set("k1", 1))
+				require.True(t, c.Set("k1", 10))
+				val, ok := c.Get("k1")
+				require.True(t, ok)
+				require.Equal(t, 10, val)
+
+				c.Set("k2", 2)
+				c.Set("k3", 3)
+				require.ElementsMatch(t, []Key{"k1", "k2", "k3"}, c.Keys())
+
+				require.True(t, c.Delete("k2"))
+				require.Equal(t, 2, c.Len())
+
+				c.Clear()
+				require.Equal(t, 0, c.Len())
+				require.Empty(t, c.Keys())
+			})
+
+			t.Run("capacity is never exceeded", func(t *testing.T) {
+				c := NewGenericCache[int, int](50, WithPolicy(p))
+				evicted := 0
+				c.OnEvict(func(int, int, EvictReason) {
+					evicted++
+				})
+
+				rnd := rand.New(rand.NewSource(1))
+				for i := 0; i < 10_000; i++ {
+					key := rnd.Intn(200)
+					switch rnd.Intn(10) {
+					case 0:
+						c.Delete(key)
+					case 1, 2, 3:
+						c.Set(key, i)
+					default:
+						if _, ok := c.Get(key); !ok {
+							c.Set(key, i)
+						}
+					}
+					require.LessOrEqual(t, c.Len(), 50)
+				}
+				require.Len(t, c.Keys(), c.Len())
+				require.Positive(t, evicted)
+
+				require.Equal(t, c.Len()-10, c.Resize(10))
+				require.Len(t, c.Keys(), 10)
+			})
+		})
+	}
+
+	t.Run("unknown policy falls back to LRU", func(t *testing.T) {
+		c := NewCache(2, WithPolicy(Policy(42)))
+		c.Set("k1", 1)
+		c.Set("k2", 2)
+		c.Get("k1")
+		c.Set("k3", 3)
+		require.Equal(t, []Key{"k3", "k1"}, c.Keys())
+		require.Equal(t, "unknown", Policy(42).String())
+	})
+}
+
+func TestLFUPolicy(t *testing.T) {
+	c := NewCache(3, WithPolicy(PolicyLFU))
+	c.Set("k1", 1)
+	c.Set("k2", 2)
+	c.Set("k3", 3)
+	for range 3 {
+		c.Get("k1")
+	}
+	c.Get("k3")
+
+	c.Set("k4", 4) // k2 используется реже всех
+	_, ok := c.Peek("k2")
+	require.False(t, ok)
+	require.Equal(t, []Key{"k1", "k3", "k4"}, c.Keys())
+
+	c.Set("k5", 5) // при равной частоте вытесняется давно используемый k4
+	require.Equal(t, []Key{"k1", "k3", "k5"}, c.Keys())
+}
+
+func Test2QPolicy(t *testing.T) {
+	c := NewCache(4, WithPolicy(Policy2Q))
+	for _, key := range []Key{"a", "b", "c", "d", "e"} {
+		c.Set(key, key)
+	}
+	// "a" вытеснен из FIFO-очереди, но остался в очереди-призраке
+	_, ok := c.Peek("a")
+	require.False(t, ok)
+
+	// Повторное добавление переводит элемент в LRU-очередь
+	c.Set("a", "a")
+	require.Equal(t, Key("a"), c.Keys()[0])
+
+	// Проход по новым ключам вытесняет только элементы FIFO-очереди
+	for i := range 20 {
+		c.Set(Key("scan"+strconv.Itoa(i)), i)
+	}
+	val, ok := c.Get("a")
+	require.True(t, ok)
+	require.Equal(t, "a", val)
+}
+
+func TestTinyLFUPolicy(t *testing.T) {
+	c := NewCache(100, WithPolicy(PolicyTinyLFU))
+	hot := make([]Key, 10)
+	for i := range hot {
+		hot[i] = Key("hot" + strconv.Itoa(i))
+		c.Set(hot[i], i)
+	}
+	// Вытесняем последний ключ из окна, чтобы повторные обращения перевели все ключи в protected
+	c.Set("filler", -1)
+	for range 10 {
+		for _, key := range hot {
+			c.Get(key)
+		}
+	}
+
+	// Редкие ключи не проходят фильтр допуска и не вытесняют часто используемые
+	for i := range 1000 {
+		c.Set(Key("cold"+strconv.Itoa(i)), i)
+	}
+	for i, key := range hot {
+		val, ok := c.Get(key)
+		require.True(t, ok, key)
+		require.Equal(t, i, val)
+	}
+	require.Equal(t, 100, c.Len())
+}
+
+func TestPolicyHitRatio(t *testing.T) {
+	traces := loadTraces(t)
+	ratios := make(map[string]map[Policy]float64)
+	for name, keys := range traces {
+		ratios[name] = make(map[Policy]float64)
+		for _, p := range allPolicies {
+			ratios[name][p] = replayTrace(NewCache(traceCapacity, WithPolicy(p)), keys)
+			t.Logf("%s %s: %.3f", name, p, ratios[name][p])
+		}
+	}
+
+	// Проходы по редким ключам вытесняют горячие элементы из LRU, но не из 2Q и W-TinyLFU
+	require.Greater(t, ratios["scan"][Policy2Q], ratios["scan"][PolicyLRU])
+	require.Greater(t, ratios["scan"][PolicyTinyLFU], ratios["scan"][PolicyLRU])
+	// Циклический проход по числу ключей больше емкости - худший случай для LRU
+	require.Zero(t, ratios["loop"][PolicyLRU])
+	require.Positive(t, ratios["loop"][PolicyTinyLFU])
+}
+
+// BenchmarkPolicyTrace воспроизводит записанные последовательности ключей из testdata/*.trace
+// для каждой политики и сообщает долю попаданий в метрике hit%.
+func BenchmarkPolicyTrace(b *testing.B) {
+	for name, keys := range loadTraces(b) {
+		for _, p := range allPolicies {
+			b.Run(name+"/"+p.String(), func(b *testing.B) {
+				var ratio float64
+				for i := 0; i < b.N; i++ {
+					ratio = replayTrace(NewCache(traceCapacity, WithPolicy(p)), keys)
+				}
+				b.ReportMetric(ratio*100, "hit%")
+			})
+		}
+	}
+}
+
+const traceCapacity = 200
+
+// replayTrace обращается к ключам по схеме "Get, при промахе Set" и возвращает долю попаданий.
+func replayTrace(c Cache, keys []Key) float64 {
+	hits := 0
+	for _, key := range keys {
+		if _, ok := c.Get(key); ok {
+			hits++
+			continue
+		}
+		c.Set(key, struct{}{})
+	}
+	return float64(hits) / float64(len(keys))
+}
+
+// loadTraces читает файлы testdata/*.trace: по одному ключу в строке, строки с # - комментарии.
+func loadTraces(tb testing.TB) map[string][]Key {
+	tb.Helper()
+
+	paths, err := filepath.Glob(filepath.Join("testdata", "*.trace"))
+	require.NoError(tb, err)
+	require.NotEmpty(tb, paths)
+
+	traces := make(map[string][]Key, len(paths))
+	for _, path := range paths {
+		f, err := os.Open(path)
+		require.NoError(tb, err)
+
+		var keys []Key
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			keys = append(keys, Key(line))
+		}
+		require.NoError(tb, scanner.Err())
+		require.NoError(tb, f.Close())
+
+		traces[strings.TrimSuffix(filepath.Base(path), ".trace")] = keys
+	}
+	return traces
+}
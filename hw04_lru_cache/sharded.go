@@ -0,0 +1,186 @@
+package hw04lrucache
+
+import (
+	"context"
+	"hash/maphash"
+	"io"
+	"time"
+)
+
+// shardedCache - кэш из независимых шардов со своими мютексами; ключ попадает в шард по хешу.
+// Вытеснение приближенное: каждый шард выбирает жертву только среди своих элементов.
+type shardedCache[K comparable, V any] struct {
+	shards  []*cache[K, V]
+	hash    func(K) uint64
+	janitor *janitor
+	codec   Codec
+}
+
+// NewShardedCache returns a cache of the given total capacity split into independent shards,
+// so that operations on keys from different shards do not contend for one lock.
+// It returns nil if capacity or shards is not positive.
+func NewShardedCache(capacity, shards int, opts ...Option) Cache {
+	seed := maphash.MakeSeed()
+	return NewGenericShardedCache[Key, interface{}](capacity, shards, func(key Key) uint64 {
+		return maphash.String(seed, string(key))
+	}, opts...)
+}
+
+// NewGenericShardedCache is NewShardedCache for arbitrary keys distributed among shards by hash.
+// Keys of the result are ordered by recency only within a shard. It returns nil if hash is nil.
+func NewGenericShardedCache[K comparable, V any](
+	capacity, shards int, hash func(K) uint64, opts ...Option,
+) GenericCache[K, V] {
+	if capacity <= 0 || shards <= 0 || hash == nil {
+		return nil
+	}
+	// Каждому шарду нужна хотя бы единица емкости
+	shards = min(shards, capacity)
+
+	o := newOptions(opts)
+	sc := &shardedCache[K, V]{
+		shards: make([]*cache[K, V], shards),
+		hash:   hash,
+		codec:  o.codec,
+	}
+	for i := range sc.shards {
+		sc.shards[i] = newCache[K, V](int64(shardCapacity(capacity, shards, i)), nil, o)
+	}
+	// Один сборщик на все шарды вместо горутины на каждый
+	if o.janitorInterval > 0 {
+		sc.janitor = startJanitor(o.clock, o.janitorInterval, func() {
+			for _, shard := range sc.shards {
+				shard.removeExpired()
+			}
+		})
+	}
+	return sc
+}
+
+// shardCapacity делит емкость между шардами; остаток достается первым шардам.
+func shardCapacity(capacity, shards, index int) int {
+	size := capacity / shards
+	if index < capacity%shards {
+		size++
+	}
+	return size
+}
+
+func (sc *shardedCache[K, V]) shard(key K) *cache[K, V] {
+	return sc.shards[sc.hash(key)%uint64(len(sc.shards))]
+}
+
+func (sc *shardedCache[K, V]) Set(key K, value V) bool {
+	return sc.shard(key).Set(key, value)
+}
+
+func (sc *shardedCache[K, V]) SetWithTTL(key K, value V, ttl time.Duration) bool {
+	return sc.shard(key).SetWithTTL(key, value, ttl)
+}
+
+func (sc *shardedCache[K, V]) TrySet(key K, value V, ttl time.Duration) (bool, error) {
+	return sc.shard(key).TrySet(key, value, ttl)
+}
+
+func (sc *shardedCache[K, V]) Get(key K) (V, bool) {
+	return sc.shard(key).Get(key)
+}
+
+func (sc *shardedCache[K, V]) GetOrLoad(ctx context.Context, key K, loader Loader[K, V]) (V, error) {
+	return sc.shard(key).GetOrLoad(ctx, key, loader)
+}
+
+func (sc *shardedCache[K, V]) Peek(key K) (V, bool) {
+	return sc.shard(key).Peek(key)
+}
+
+func (sc *shardedCache[K, V]) Delete(key K) bool {
+	return sc.shard(key).Delete(key)
+}
+
+func (sc *shardedCache[K, V]) Len() int {
+	total := 0
+	for _, shard := range sc.shards {
+		total += shard.Len()
+	}
+	return total
+}
+
+func (sc *shardedCache[K, V]) Keys() []K {
+	keys := make([]K, 0, sc.Len())
+	for _, shard := range sc.shards {
+		keys = append(keys, shard.Keys()...)
+	}
+	return keys
+}
+
+// Resize делит новую емкость между шардами так же, как при создании кэша.
+// Емкость меньше числа шардов округляется до одного элемента на шард.
+func (sc *shardedCache[K, V]) Resize(capacity int) int {
+	if capacity <= 0 {
+		return 0
+	}
+	capacity = max(capacity, len(sc.shards))
+
+	evicted := 0
+	for i, shard := range sc.shards {
+		evicted += shard.Resize(shardCapacity(capacity, len(sc.shards), i))
+	}
+	return evicted
+}
+
+func (sc *shardedCache[K, V]) Clear() {
+	for _, shard := range sc.shards {
+		shard.Clear()
+	}
+}
+
+func (sc *shardedCache[K, V]) Close() error {
+	if sc.janitor != nil {
+		sc.janitor.stop()
+	}
+	return nil
+}
+
+func (sc *shardedCache[K, V]) OnEvict(fn func(key K, value V, reason EvictReason)) {
+	for _, shard := range sc.shards {
+		shard.OnEvict(fn)
+	}
+}
+
+func (sc *shardedCache[K, V]) Stats() Stats {
+	var total Stats
+	for _, shard := range sc.shards {
+		total = total.add(shard.Stats())
+	}
+	return total
+}
+
+// Snapshot пишет элементы шардов друг за другом, поэтому порядок недавности,
+// как и в Keys, соблюдается только внутри шарда.
+func (sc *shardedCache[K, V]) Snapshot(w io.Writer) error {
+	var entries []snapshotEntry[K, V]
+	for _, shard := range sc.shards {
+		entries = append(entries, shard.snapshotEntries()...)
+	}
+	return sc.codec.Encode(w, snapshot[K, V]{Entries: entries})
+}
+
+// Restore раскладывает элементы по шардам заново, так что снимок можно загрузить
+// в кэш с другим числом шардов.
+func (sc *shardedCache[K, V]) Restore(r io.Reader) error {
+	var s snapshot[K, V]
+	if err := sc.codec.Decode(r, &s); err != nil {
+		return err
+	}
+
+	perShard := make([][]snapshotEntry[K, V], len(sc.shards))
+	for _, entry := range s.Entries {
+		i := sc.hash(entry.Key) % uint64(len(sc.shards))
+		perShard[i] = append(perShard[i], entry)
+	}
+	for i, shard := range sc.shards {
+		shard.restoreEntries(perShard[i])
+	}
+	return nil
+}
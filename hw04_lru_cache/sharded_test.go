@@ -0,0 +1,179 @@
+package hw04lrucache
+
+import (
+	"math/rand"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// modHash раскладывает целые ключи по шардам предсказуемо.
+func modHash(key int) uint64 {
+	return uint64(key)
+}
+
+func TestShardedCache(t *testing.T) {
+	t.Run("simple", func(t *testing.T) {
+		c := NewShardedCache(100, 8)
+
+		require.False(t, c.Set("aaa", 100))
+		require.True(t, c.Set("aaa", 200))
+
+		val, ok := c.Get("aaa")
+		require.True(t, ok)
+		require.Equal(t, 200, val)
+
+		_, ok = c.Get("bbb")
+		require.False(t, ok)
+
+		require.True(t, c.Delete("aaa"))
+		require.Equal(t, 0, c.Len())
+	})
+
+	t.Run("empty cache keys match plain cache", func(t *testing.T) {
+		c := NewShardedCache(10, 4)
+		require.NotNil(t, c.Keys())
+		require.Empty(t, c.Keys())
+		require.Equal(t, NewCache(10).Keys(), c.Keys())
+	})
+
+	t.Run("bad arguments", func(t *testing.T) {
+		require.Nil(t, NewShardedCache(0, 4))
+		require.Nil(t, NewShardedCache(10, 0))
+		require.NotNil(t, NewShardedCache(2, 4))
+		require.Nil(t, NewGenericShardedCache[int, int](10, 2, nil))
+	})
+
+	t.Run("capacity is split between shards", func(t *testing.T) {
+		c := NewGenericShardedCache[int, int](10, 4, modHash)
+		for i := range 100 {
+			c.Set(i, i)
+		}
+		// Шарды получают емкости 3, 3, 2, 2
+		require.Equal(t, 10, c.Len())
+		require.ElementsMatch(t, []int{96, 92, 88, 97, 93, 89, 98, 94, 99, 95}, c.Keys())
+	})
+
+	t.Run("shards evict independently", func(t *testing.T) {
+		c := NewGenericShardedCache[int, int](4, 2, modHash)
+		c.Set(0, 0)
+		c.Set(2, 2)
+		c.Set(1, 1)
+		c.Get(0)
+		c.Set(4, 4) // вытесняет 2 из четного шарда, нечетный не затронут
+
+		require.Equal(t, []int{4, 0, 1}, c.Keys())
+	})
+
+	t.Run("resize", func(t *testing.T) {
+		c := NewGenericShardedCache[int, int](8, 2, modHash)
+		for i := range 8 {
+			c.Set(i, i)
+		}
+
+		require.Equal(t, 4, c.Resize(4))
+		require.ElementsMatch(t, []int{6, 4, 7, 5}, c.Keys())
+
+		require.Equal(t, 2, c.Resize(1)) // По одному элементу на шард
+		require.ElementsMatch(t, []int{6, 7}, c.Keys())
+	})
+
+	t.Run("ttl, hooks and janitor", func(t *testing.T) {
+		clock := newFakeClock()
+		c := NewGenericShardedCache[int, int](10, 3, modHash, WithClock(clock), WithJanitor(time.Second))
+		defer c.Close()
+
+		var mutex sync.Mutex
+		var expired []int
+		c.OnEvict(func(key int, _ int, reason EvictReason) {
+			mutex.Lock()
+			defer mutex.Unlock()
+			if reason == EvictExpired {
+				expired = append(expired, key)
+			}
+		})
+
+		for i := range 6 {
+			c.SetWithTTL(i, i, time.Duration(i%2+1)*time.Minute)
+		}
+		clock.Advance(time.Minute)
+		clock.Tick()
+		clock.Tick()
+
+		require.ElementsMatch(t, []int{0, 2, 4}, expired)
+		require.ElementsMatch(t, []int{1, 3, 5}, c.Keys())
+
+		c.Clear()
+		require.Equal(t, 0, c.Len())
+		require.NoError(t, c.Close())
+		require.True(t, clock.ticker.Stopped())
+	})
+
+	t.Run("multithreading", func(t *testing.T) {
+		c := NewShardedCache(100, 8)
+		wg := &sync.WaitGroup{}
+		for range 4 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := 0; i < 10_000; i++ {
+					key := Key(strconv.Itoa(rand.Intn(1_000)))
+					c.Set(key, i)
+					c.Get(key)
+				}
+			}()
+		}
+		wg.Wait()
+
+		require.LessOrEqual(t, c.Len(), 100)
+	})
+}
+
+// BenchmarkCacheParallel сравнивает пропускную способность кэша с одним мютексом и шардированного
+// кэша при параллельной нагрузке: 90% чтений и 10% записей.
+func BenchmarkCacheParallel(b *testing.B) {
+	const (
+		capacity = 1 << 14
+		keyCount = capacity * 2
+	)
+	keys := make([]Key, keyCount)
+	for i := range keys {
+		keys[i] = Key(strconv.Itoa(i))
+	}
+
+	caches := []struct {
+		name string
+		new  func() Cache
+	}{
+		{name: "single lock", new: func() Cache { return NewCache(capacity) }},
+		{name: "4 shards", new: func() Cache { return NewShardedCache(capacity, 4) }},
+		{name: "16 shards", new: func() Cache { return NewShardedCache(capacity, 16) }},
+		{name: "64 shards", new: func() Cache { return NewShardedCache(capacity, 64) }},
+	}
+
+	for _, bc := range caches {
+		b.Run(bc.name, func(b *testing.B) {
+			c := bc.new()
+			for _, key := range keys[:capacity] {
+				c.Set(key, key)
+			}
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			b.RunParallel(func(pb *testing.PB) {
+				rnd := rand.New(rand.NewSource(rand.Int63()))
+				for pb.Next() {
+					key := keys[rnd.Intn(keyCount)]
+					if rnd.Intn(10) == 0 {
+						c.Set(key, key)
+					} else {
+						c.Get(key)
+					}
+				}
+			})
+		})
+	}
+}
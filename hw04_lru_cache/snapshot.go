@@ -0,0 +1,106 @@
+package hw04lrucache
+
+import (
+	"encoding/gob"
+	"encoding/json"
+	"io"
+	"slices"
+	"time"
+)
+
+// Codec encodes cache snapshots written by Snapshot and decodes them in Restore.
+type Codec interface {
+	Encode(w io.Writer, v any) error
+	Decode(r io.Reader, v any) error
+}
+
+var (
+	// GobCodec stores snapshots in the encoding/gob format. It is the default codec.
+	// Concrete types stored in interface{} values must be registered with gob.Register.
+	GobCodec Codec = gobCodec{}
+	// JSONCodec stores snapshots as JSON. Values of an untyped Cache are restored
+	// as the types produced by encoding/json, e.g. float64 for numbers.
+	JSONCodec Codec = jsonCodec{}
+)
+
+type gobCodec struct{}
+
+func (gobCodec) Encode(w io.Writer, v any) error {
+	return gob.NewEncoder(w).Encode(v)
+}
+
+func (gobCodec) Decode(r io.Reader, v any) error {
+	return gob.NewDecoder(r).Decode(v)
+}
+
+type jsonCodec struct{}
+
+func (jsonCodec) Encode(w io.Writer, v any) error {
+	return json.NewEncoder(w).Encode(v)
+}
+
+func (jsonCodec) Decode(r io.Reader, v any) error {
+	return json.NewDecoder(r).Decode(v)
+}
+
+// snapshot - сериализуемое содержимое кэша. Элементы идут от самого ценного для политики
+// (для LRU - самого недавнего) к наименее ценному.
+type snapshot[K comparable, V any] struct {
+	Entries []snapshotEntry[K, V] `json:"entries"`
+}
+
+type snapshotEntry[K comparable, V any] struct {
+	Key       K         `json:"key"`
+	Value     V         `json:"value"`
+	ExpiresAt time.Time `json:"expiresAt"` // Нулевое время - элемент не устаревает
+}
+
+func (c *cache[K, V]) Snapshot(w io.Writer) error {
+	return c.codec.Encode(w, snapshot[K, V]{Entries: c.snapshotEntries()})
+}
+
+func (c *cache[K, V]) Restore(r io.Reader) error {
+	var s snapshot[K, V]
+	if err := c.codec.Decode(r, &s); err != nil {
+		return err
+	}
+	c.restoreEntries(s.Entries)
+	return nil
+}
+
+// snapshotEntries копирует живые элементы в порядке политики.
+func (c *cache[K, V]) snapshotEntries() []snapshotEntry[K, V] {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	now := c.clock.Now()
+	entries := make([]snapshotEntry[K, V], 0, len(c.items))
+	for entry := range c.policy.all() {
+		if !entry.expired(now) {
+			entries = append(entries, snapshotEntry[K, V]{Key: entry.key, Value: entry.value, ExpiresAt: entry.expiresAt})
+		}
+	}
+	return entries
+}
+
+// restoreEntries добавляет элементы снимка поверх текущего содержимого. Элементы добавляются
+// с конца, чтобы первый элемент снимка стал самым недавним, как при сохранении.
+// Устаревшие к моменту загрузки элементы и элементы тяжелее всего кэша пропускаются.
+func (c *cache[K, V]) restoreEntries(entries []snapshotEntry[K, V]) {
+	// Вес считаем до блокировки, как и в TrySet
+	weights := make([]int64, len(entries))
+	for i, entry := range entries {
+		weights[i] = c.weigh(entry.Key, entry.Value)
+	}
+
+	c.mutex.Lock()
+	defer c.unlock()
+
+	now := c.clock.Now()
+	for i, entry := range slices.Backward(entries) {
+		if !entry.ExpiresAt.IsZero() && !now.Before(entry.ExpiresAt) {
+			continue
+		}
+		_, _ = c.set(entry.Key, entry.Value, entry.ExpiresAt, weights[i])
+	}
+}
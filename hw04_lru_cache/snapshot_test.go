@@ -0,0 +1,145 @@
+package hw04lrucache
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshot(t *testing.T) {
+	codecs := []struct {
+		name  string
+		codec Codec
+	}{
+		{name: "gob", codec: GobCodec},
+		{name: "json", codec: JSONCodec},
+	}
+
+	for _, tc := range codecs {
+		t.Run(tc.name, func(t *testing.T) {
+			clock := newFakeClock()
+			c := NewGenericCache[string, int](3, WithClock(clock), WithCodec(tc.codec))
+			c.Set("a", 1)
+			c.Set("b", 2)
+			c.SetWithTTL("c", 3, time.Minute)
+			c.Get("a")
+
+			var buf bytes.Buffer
+			require.NoError(t, c.Snapshot(&buf))
+
+			restored := NewGenericCache[string, int](3, WithClock(clock), WithCodec(tc.codec))
+			require.NoError(t, restored.Restore(&buf))
+			require.Equal(t, []string{"a", "c", "b"}, restored.Keys())
+
+			value, ok := restored.Peek("c")
+			require.True(t, ok)
+			require.Equal(t, 3, value)
+
+			// Срок жизни сохраняется вместе с элементом
+			clock.Advance(time.Minute)
+			_, ok = restored.Get("c")
+			require.False(t, ok)
+		})
+	}
+
+	t.Run("expired entries are skipped", func(t *testing.T) {
+		clock := newFakeClock()
+		c := NewGenericCache[string, int](3, WithClock(clock))
+		c.SetWithTTL("short", 1, time.Second)
+		c.SetWithTTL("long", 2, time.Hour)
+		c.Set("forever", 3)
+
+		var buf bytes.Buffer
+		require.NoError(t, c.Snapshot(&buf))
+		snapshotBytes := buf.Bytes()
+
+		clock.Advance(time.Second)
+		var afterExpiry bytes.Buffer
+		require.NoError(t, c.Snapshot(&afterExpiry))
+
+		// Элемент, устаревший до сохранения, не попадает в снимок
+		restored := NewGenericCache[string, int](3, WithClock(clock))
+		require.NoError(t, restored.Restore(&afterExpiry))
+		require.Equal(t, []string{"forever", "long"}, restored.Keys())
+
+		// Элемент, устаревший после сохранения, пропускается при загрузке
+		restored = NewGenericCache[string, int](3, WithClock(clock))
+		require.NoError(t, restored.Restore(bytes.NewReader(snapshotBytes)))
+		require.Equal(t, []string{"forever", "long"}, restored.Keys())
+		require.Equal(t, 2, restored.Len())
+	})
+
+	t.Run("restore into smaller cache keeps most recent", func(t *testing.T) {
+		c := NewGenericCache[int, int](5)
+		for i := range 5 {
+			c.Set(i, i)
+		}
+
+		var buf bytes.Buffer
+		require.NoError(t, c.Snapshot(&buf))
+
+		restored := NewGenericCache[int, int](2)
+		require.NoError(t, restored.Restore(&buf))
+		require.Equal(t, []int{4, 3}, restored.Keys())
+	})
+
+	t.Run("restore merges with current contents", func(t *testing.T) {
+		c := NewGenericCache[string, int](3)
+		c.Set("a", 1)
+
+		var buf bytes.Buffer
+		require.NoError(t, c.Snapshot(&buf))
+
+		restored := NewGenericCache[string, int](3)
+		restored.Set("a", 10)
+		restored.Set("b", 2)
+		require.NoError(t, restored.Restore(&buf))
+
+		require.Equal(t, []string{"a", "b"}, restored.Keys())
+		value, _ := restored.Peek("a")
+		require.Equal(t, 1, value)
+	})
+
+	t.Run("untyped cache", func(t *testing.T) {
+		c := NewCache(2)
+		c.Set("a", 1)
+		c.Set("b", "two")
+
+		var buf bytes.Buffer
+		require.NoError(t, c.Snapshot(&buf))
+
+		restored := NewCache(2)
+		require.NoError(t, restored.Restore(&buf))
+		value, _ := restored.Get("a")
+		require.Equal(t, 1, value)
+		value, _ = restored.Get("b")
+		require.Equal(t, "two", value)
+	})
+
+	t.Run("sharded cache with other shard count", func(t *testing.T) {
+		c := NewGenericShardedCache[int, int](6, 3, modHash)
+		for i := range 6 {
+			c.Set(i, i*10)
+		}
+
+		var buf bytes.Buffer
+		require.NoError(t, c.Snapshot(&buf))
+
+		restored := NewGenericShardedCache[int, int](6, 2, modHash)
+		require.NoError(t, restored.Restore(&buf))
+		require.ElementsMatch(t, []int{0, 1, 2, 3, 4, 5}, restored.Keys())
+		value, ok := restored.Get(5)
+		require.True(t, ok)
+		require.Equal(t, 50, value)
+	})
+
+	t.Run("corrupted snapshot leaves cache untouched", func(t *testing.T) {
+		c := NewGenericCache[string, int](2)
+		c.Set("a", 1)
+
+		require.Error(t, c.Restore(bytes.NewReader([]byte("garbage"))))
+		require.Equal(t, []string{"a"}, c.Keys())
+	})
+}
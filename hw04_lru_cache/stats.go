@@ -0,0 +1,53 @@
+package hw04lrucache
+
+import "sync/atomic"
+
+// Stats is a snapshot of the cache counters.
+type Stats struct {
+	Hits   uint64 // Get calls that found a live entry
+	Misses uint64 // Get calls that found nothing or an expired entry
+	// Evictions counts entries removed by the cache itself: displaced by capacity or expired.
+	// Delete and Clear are not counted.
+	Evictions uint64
+	Sets      uint64 // Entries added under a new or expired key
+	Updates   uint64 // Values replaced under a live key
+}
+
+// HitRatio returns the share of Get calls that were hits, or 0 if there were none.
+func (s Stats) HitRatio() float64 {
+	total := s.Hits + s.Misses
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(total)
+}
+
+// add суммирует счетчики, например, шардов одного кэша.
+func (s Stats) add(other Stats) Stats {
+	return Stats{
+		Hits:      s.Hits + other.Hits,
+		Misses:    s.Misses + other.Misses,
+		Evictions: s.Evictions + other.Evictions,
+		Sets:      s.Sets + other.Sets,
+		Updates:   s.Updates + other.Updates,
+	}
+}
+
+// counters - счетчики кэша. Атомарные, чтобы Stats не ждал блокировку кэша.
+type counters struct {
+	hits      atomic.Uint64
+	misses    atomic.Uint64
+	evictions atomic.Uint64
+	sets      atomic.Uint64
+	updates   atomic.Uint64
+}
+
+func (c *counters) snapshot() Stats {
+	return Stats{
+		Hits:      c.hits.Load(),
+		Misses:    c.misses.Load(),
+		Evictions: c.evictions.Load(),
+		Sets:      c.sets.Load(),
+		Updates:   c.updates.Load(),
+	}
+}
@@ -0,0 +1,77 @@
+package hw04lrucache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStats(t *testing.T) {
+	t.Run("counts reads and writes", func(t *testing.T) {
+		c := NewGenericCache[string, int](2)
+
+		c.Set("a", 1)
+		c.Set("a", 2)
+		c.Set("b", 3)
+		c.Get("a")
+		c.Get("a")
+		c.Get("missing")
+		c.Peek("b")
+
+		require.Equal(t, Stats{Hits: 2, Misses: 1, Sets: 2, Updates: 1}, c.Stats())
+		require.InDelta(t, 2.0/3, c.Stats().HitRatio(), 1e-9)
+	})
+
+	t.Run("counts evictions by capacity and expiration", func(t *testing.T) {
+		clock := newFakeClock()
+		c := NewGenericCache[string, int](2, WithClock(clock))
+
+		c.Set("a", 1)
+		c.Set("b", 2)
+		c.Set("c", 3)                     // Вытесняет a
+		c.SetWithTTL("d", 4, time.Second) // Вытесняет b
+		clock.Advance(time.Second)
+		c.Get("d")
+
+		stats := c.Stats()
+		require.Equal(t, uint64(3), stats.Evictions)
+		require.Equal(t, uint64(1), stats.Misses)
+	})
+
+	t.Run("overwriting expired entry is a set", func(t *testing.T) {
+		clock := newFakeClock()
+		c := NewGenericCache[string, int](2, WithClock(clock))
+
+		c.SetWithTTL("a", 1, time.Second)
+		clock.Advance(time.Second)
+		c.Set("a", 2)
+
+		require.Equal(t, Stats{Evictions: 1, Sets: 2}, c.Stats())
+	})
+
+	t.Run("delete and clear are not evictions", func(t *testing.T) {
+		c := NewGenericCache[string, int](3)
+		c.Set("a", 1)
+		c.Set("b", 2)
+		c.Delete("a")
+		c.Clear()
+
+		require.Zero(t, c.Stats().Evictions)
+	})
+
+	t.Run("no reads", func(t *testing.T) {
+		require.Zero(t, NewCache(1).Stats().HitRatio())
+	})
+
+	t.Run("sharded cache sums shards", func(t *testing.T) {
+		c := NewGenericShardedCache[int, int](4, 2, modHash)
+		for i := range 4 {
+			c.Set(i, i)
+			c.Get(i)
+		}
+		c.Get(10)
+
+		require.Equal(t, Stats{Hits: 4, Misses: 1, Sets: 4}, c.Stats())
+	})
+}
@@ -0,0 +1,280 @@
+package hw04lrucache
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/maphash"
+	"iter"
+)
+
+// tinyLFUPolicy - W-TinyLFU (Einziger, Friedman, Manes). Новые элементы попадают в окно -
+// LRU-очередь размером 1% емкости. Элемент, вытесняемый из окна, становится кандидатом
+// в основную область и вытесняет ее жертву, только если встречался чаще. Основная область -
+// сегментированный LRU: элементы приходят в probation и при повторном обращении
+// переходят в protected. Частоты оцениваются count-min sketch со старением.
+// Размеры сегментов считаются в весе элементов.
+type tinyLFUPolicy[K comparable, V any] struct {
+	window    GenericList[*cacheEntry[K, V]]
+	probation GenericList[*cacheEntry[K, V]]
+	protected GenericList[*cacheEntry[K, V]]
+
+	windowWeight      int64
+	probationWeight   int64
+	protectedWeight   int64
+	windowCapacity    int64
+	protectedCapacity int64
+
+	sketch *countMinSketch
+	seed   maphash.Seed
+}
+
+func newTinyLFUPolicy[K comparable, V any](capacity int64) *tinyLFUPolicy[K, V] {
+	p := &tinyLFUPolicy[K, V]{seed: maphash.MakeSeed()}
+	p.reset()
+	p.setCapacity(capacity)
+	return p
+}
+
+func (p *tinyLFUPolicy[K, V]) add(entry *cacheEntry[K, V]) {
+	p.ensureSketch()
+	p.sketch.increment(hashKey(p.seed, entry.key))
+	entry.segment = segmentWindow
+	entry.node = p.window.PushFront(entry)
+	p.windowWeight += entry.weight
+
+	// Пока кэш не заполнен, вытесняемые из окна элементы переходят в основную область без отбора
+	for p.windowWeight > p.windowCapacity {
+		p.move(p.window.Back().Value, segmentProbation)
+	}
+}
+
+func (p *tinyLFUPolicy[K, V]) hit(entry *cacheEntry[K, V]) {
+	p.sketch.increment(hashKey(p.seed, entry.key))
+
+	switch entry.segment {
+	case segmentProbation:
+		p.move(entry, segmentProtected)
+		p.demoteProtected()
+	case segmentProtected:
+		p.protected.MoveToFront(entry.node)
+	case segmentWindow:
+		p.window.MoveToFront(entry.node)
+	case segmentMain, segmentRecent: // Сегменты 2Q в W-TinyLFU не встречаются
+	}
+}
+
+func (p *tinyLFUPolicy[K, V]) reweigh(entry *cacheEntry[K, V], oldWeight int64) {
+	*p.weight(entry.segment) += entry.weight - oldWeight
+}
+
+func (p *tinyLFUPolicy[K, V]) remove(entry *cacheEntry[K, V], _ EvictReason) {
+	p.list(entry.segment).Remove(entry.node)
+	*p.weight(entry.segment) -= entry.weight
+}
+
+func (p *tinyLFUPolicy[K, V]) victim(keep *cacheEntry[K, V]) *cacheEntry[K, V] {
+	// Кандидат из окна появляется, только когда окно заполнено и новый элемент его вытеснит.
+	// Иначе переполнена основная область, и жертва выбирается в ней
+	var candidate *cacheEntry[K, V]
+	if p.windowWeight >= p.windowCapacity {
+		candidate = backExcept(p.window, keep)
+	}
+	victim := p.mainVictim(keep)
+
+	switch {
+	case candidate == nil:
+		return victim
+	case victim == nil:
+		return candidate
+	}
+
+	// Фильтр допуска: кандидат вытесняет жертву, только если встречался чаще
+	if p.frequency(candidate.key) > p.frequency(victim.key) {
+		p.move(candidate, segmentProbation)
+		return victim
+	}
+	return candidate
+}
+
+func (p *tinyLFUPolicy[K, V]) all() iter.Seq[*cacheEntry[K, V]] {
+	return listEntries(p.protected, p.probation, p.window)
+}
+
+func (p *tinyLFUPolicy[K, V]) setCapacity(capacity int64) {
+	p.windowCapacity = max(1, capacity/100)
+	p.protectedCapacity = (capacity - p.windowCapacity) * 8 / 10
+	p.demoteProtected()
+}
+
+func (p *tinyLFUPolicy[K, V]) reset() {
+	p.window = NewGenericList[*cacheEntry[K, V]]()
+	p.probation = NewGenericList[*cacheEntry[K, V]]()
+	p.protected = NewGenericList[*cacheEntry[K, V]]()
+	p.windowWeight, p.probationWeight, p.protectedWeight = 0, 0, 0
+	p.sketch = newCountMinSketch(minSketchCapacity)
+}
+
+// ensureSketch увеличивает sketch вслед за числом элементов: емкость задана в весе,
+// и заранее неизвестно, сколько элементов в нее поместится. Счетчики при этом сбрасываются.
+func (p *tinyLFUPolicy[K, V]) ensureSketch() {
+	entries := p.window.Len() + p.probation.Len() + p.protected.Len() + 1
+	if entries > p.sketch.capacity {
+		p.sketch = newCountMinSketch(2 * p.sketch.capacity)
+	}
+}
+
+func (p *tinyLFUPolicy[K, V]) list(seg segment) GenericList[*cacheEntry[K, V]] {
+	switch seg {
+	case segmentProbation:
+		return p.probation
+	case segmentProtected:
+		return p.protected
+	case segmentWindow:
+		return p.window
+	case segmentMain, segmentRecent: // Сегменты 2Q в W-TinyLFU не встречаются
+	}
+	return p.window
+}
+
+func (p *tinyLFUPolicy[K, V]) weight(seg segment) *int64 {
+	switch seg {
+	case segmentProbation:
+		return &p.probationWeight
+	case segmentProtected:
+		return &p.protectedWeight
+	case segmentWindow:
+		return &p.windowWeight
+	case segmentMain, segmentRecent: // Сегменты 2Q в W-TinyLFU не встречаются
+	}
+	return &p.windowWeight
+}
+
+// move переносит элемент в начало другого сегмента.
+func (p *tinyLFUPolicy[K, V]) move(entry *cacheEntry[K, V], to segment) {
+	p.remove(entry, EvictDeleted)
+	entry.segment = to
+	entry.node = p.list(to).PushFront(entry)
+	*p.weight(to) += entry.weight
+}
+
+func (p *tinyLFUPolicy[K, V]) mainVictim(keep *cacheEntry[K, V]) *cacheEntry[K, V] {
+	if victim := backExcept(p.probation, keep); victim != nil {
+		return victim
+	}
+	return backExcept(p.protected, keep)
+}
+
+// demoteProtected возвращает в probation элементы, не поместившиеся в protected.
+func (p *tinyLFUPolicy[K, V]) demoteProtected() {
+	for p.protectedWeight > p.protectedCapacity {
+		p.move(p.protected.Back().Value, segmentProbation)
+	}
+}
+
+func (p *tinyLFUPolicy[K, V]) frequency(key K) uint8 {
+	return p.sketch.estimate(hashKey(p.seed, key))
+}
+
+const (
+	minSketchCapacity = 64
+	sketchDepth       = 4
+	sketchMaxCount    = 15 // Счетчики насыщаются, как 4-битные в оригинальной работе
+	sketchSampleSize  = 10 // Старение после capacity*sketchSampleSize добавлений
+)
+
+// countMinSketch - приближенная оценка частот: минимум из sketchDepth счетчиков по разным хешам.
+// Периодически все счетчики делятся пополам, чтобы старые обращения теряли вес.
+type countMinSketch struct {
+	rows      [sketchDepth][]uint8
+	shift     uint // Индекс в строке - старшие биты перемешанного хеша
+	capacity  int
+	additions int
+	resetAt   int
+}
+
+func newCountMinSketch(capacity int) *countMinSketch {
+	// Как в Caffeine: по 16 счетчиков на элемент кэша, по 4 на каждую строку
+	width, shift := 16, uint(60)
+	for width < capacity*sketchDepth {
+		width <<= 1
+		shift--
+	}
+	s := &countMinSketch{
+		shift:    shift,
+		capacity: capacity,
+		resetAt:  max(1, capacity*sketchSampleSize),
+	}
+	for i := range s.rows {
+		s.rows[i] = make([]uint8, width)
+	}
+	return s
+}
+
+// increment использует консервативное обновление: увеличиваются только минимальные счетчики,
+// что уменьшает переоценку частот редких ключей из-за коллизий.
+func (s *countMinSketch) increment(hash uint64) {
+	estimate := s.estimate(hash)
+	if estimate < sketchMaxCount {
+		for i := range s.rows {
+			if counter := &s.rows[i][s.index(hash, i)]; *counter == estimate {
+				*counter++
+			}
+		}
+	}
+
+	s.additions++
+	if s.additions >= s.resetAt {
+		s.age()
+	}
+}
+
+func (s *countMinSketch) estimate(hash uint64) uint8 {
+	estimate := uint8(sketchMaxCount)
+	for i := range s.rows {
+		estimate = min(estimate, s.rows[i][s.index(hash, i)])
+	}
+	return estimate
+}
+
+func (s *countMinSketch) age() {
+	for i := range s.rows {
+		for j := range s.rows[i] {
+			s.rows[i][j] >>= 1
+		}
+	}
+	s.additions /= 2
+}
+
+// sketchSeeds - нечетные множители, дающие для каждой строки свое перемешивание всех битов хеша.
+var sketchSeeds = [sketchDepth]uint64{
+	0x9E3779B97F4A7C15, 0xC2B2AE3D27D4EB4F, 0x165667B19E3779F9, 0xD6E8FEB86659FD93,
+}
+
+func (s *countMinSketch) index(hash uint64, row int) uint64 {
+	return (hash * sketchSeeds[row]) >> s.shift
+}
+
+// hashKey хеширует ключ произвольного сравнимого типа. Строки и целые числа хешируются
+// напрямую, остальные типы - по текстовому представлению: для оценки частот
+// неточность хеша допустима.
+func hashKey[K comparable](seed maphash.Seed, key K) uint64 {
+	var buf [8]byte
+	switch k := any(key).(type) {
+	case string:
+		return maphash.String(seed, k)
+	case Key:
+		return maphash.String(seed, string(k))
+	case int:
+		binary.LittleEndian.PutUint64(buf[:], uint64(k))
+	case int64:
+		binary.LittleEndian.PutUint64(buf[:], uint64(k))
+	case uint64:
+		binary.LittleEndian.PutUint64(buf[:], k)
+	default:
+		var h maphash.Hash
+		h.SetSeed(seed)
+		fmt.Fprint(&h, key)
+		return h.Sum64()
+	}
+	return maphash.Bytes(seed, buf[:])
+}
@@ -0,0 +1,122 @@
+package hw04lrucache
+
+import "iter"
+
+// twoQueuePolicy - полная версия алгоритма 2Q (Johnson, Shasha). Новые элементы попадают
+// в FIFO-очередь recent, вытесненные из нее ключи запоминаются в очереди-призраке ghost.
+// Повторное добавление ключа из ghost помещает элемент в LRU-очередь frequent,
+// поэтому однократный проход по множеству ключей не вытесняет часто используемые элементы.
+// Размеры очередей считаются в весе элементов.
+type twoQueuePolicy[K comparable, V any] struct {
+	recent     GenericList[*cacheEntry[K, V]]
+	frequent   GenericList[*cacheEntry[K, V]]
+	ghost      GenericList[ghostEntry[K]]
+	ghostItems map[K]*GenericListItem[ghostEntry[K]]
+
+	recentWeight   int64
+	ghostWeight    int64
+	recentCapacity int64
+	ghostCapacity  int64
+}
+
+// ghostEntry - ключ вытесненного элемента и его вес.
+type ghostEntry[K comparable] struct {
+	key    K
+	weight int64
+}
+
+func newTwoQueuePolicy[K comparable, V any](capacity int64) *twoQueuePolicy[K, V] {
+	p := &twoQueuePolicy[K, V]{}
+	p.reset()
+	p.setCapacity(capacity)
+	return p
+}
+
+func (p *twoQueuePolicy[K, V]) add(entry *cacheEntry[K, V]) {
+	// Ключ недавно вытеснялся из recent - значит, к нему обращаются повторно
+	if item, ok := p.ghostItems[entry.key]; ok {
+		p.forget(item)
+		entry.segment = segmentMain
+		entry.node = p.frequent.PushFront(entry)
+		return
+	}
+	entry.segment = segmentRecent
+	entry.node = p.recent.PushFront(entry)
+	p.recentWeight += entry.weight
+}
+
+func (p *twoQueuePolicy[K, V]) hit(entry *cacheEntry[K, V]) {
+	// Обращения к элементам recent не меняют их порядок: это FIFO
+	if entry.segment == segmentMain {
+		p.frequent.MoveToFront(entry.node)
+	}
+}
+
+func (p *twoQueuePolicy[K, V]) reweigh(entry *cacheEntry[K, V], oldWeight int64) {
+	if entry.segment == segmentRecent {
+		p.recentWeight += entry.weight - oldWeight
+	}
+}
+
+func (p *twoQueuePolicy[K, V]) remove(entry *cacheEntry[K, V], reason EvictReason) {
+	if entry.segment == segmentMain {
+		p.frequent.Remove(entry.node)
+		return
+	}
+	p.recent.Remove(entry.node)
+	p.recentWeight -= entry.weight
+	if reason == EvictCapacity {
+		p.remember(entry)
+	}
+}
+
+func (p *twoQueuePolicy[K, V]) victim(keep *cacheEntry[K, V]) *cacheEntry[K, V] {
+	if p.recentWeight > p.recentCapacity || p.frequent.Len() == 0 {
+		if victim := backExcept(p.recent, keep); victim != nil {
+			return victim
+		}
+	}
+	// Если в очереди остался только keep, жертву берем из другой очереди
+	if victim := backExcept(p.frequent, keep); victim != nil {
+		return victim
+	}
+	return backExcept(p.recent, keep)
+}
+
+func (p *twoQueuePolicy[K, V]) all() iter.Seq[*cacheEntry[K, V]] {
+	return listEntries(p.frequent, p.recent)
+}
+
+// setCapacity задает размеры очередей по рекомендации авторов: 25% емкости для recent
+// и ключи 50% емкости для ghost.
+func (p *twoQueuePolicy[K, V]) setCapacity(capacity int64) {
+	p.recentCapacity = max(1, capacity/4)
+	p.ghostCapacity = max(1, capacity/2)
+	p.trimGhost()
+}
+
+func (p *twoQueuePolicy[K, V]) reset() {
+	p.recent = NewGenericList[*cacheEntry[K, V]]()
+	p.frequent = NewGenericList[*cacheEntry[K, V]]()
+	p.ghost = NewGenericList[ghostEntry[K]]()
+	p.ghostItems = make(map[K]*GenericListItem[ghostEntry[K]])
+	p.recentWeight, p.ghostWeight = 0, 0
+}
+
+func (p *twoQueuePolicy[K, V]) remember(entry *cacheEntry[K, V]) {
+	p.ghostItems[entry.key] = p.ghost.PushFront(ghostEntry[K]{key: entry.key, weight: entry.weight})
+	p.ghostWeight += entry.weight
+	p.trimGhost()
+}
+
+func (p *twoQueuePolicy[K, V]) forget(item *GenericListItem[ghostEntry[K]]) {
+	delete(p.ghostItems, item.Value.key)
+	p.ghost.Remove(item)
+	p.ghostWeight -= item.Value.weight
+}
+
+func (p *twoQueuePolicy[K, V]) trimGhost() {
+	for p.ghostWeight > p.ghostCapacity {
+		p.forget(p.ghost.Back())
+	}
+}
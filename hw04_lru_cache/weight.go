@@ -0,0 +1,32 @@
+package hw04lrucache
+
+import "errors"
+
+// ErrTooHeavy is returned by TrySet for an entry that weighs more than the whole cache capacity.
+var ErrTooHeavy = errors.New("entry is heavier than the cache capacity")
+
+// Weigher returns the cost of an entry, for example the size of the value in bytes.
+// Weights below one are counted as one.
+type Weigher[K comparable, V any] func(key K, value V) int64
+
+// NewWeightedCache returns a cache that limits the total weight of its entries instead of
+// their number. When a new entry does not fit, entries are evicted until it does.
+// It returns nil if maxWeight is not positive or weigher is nil.
+func NewWeightedCache(maxWeight int64, weigher Weigher[Key, interface{}], opts ...Option) Cache {
+	return NewGenericWeightedCache(maxWeight, weigher, opts...)
+}
+
+// NewGenericWeightedCache is NewWeightedCache for arbitrary keys and values.
+func NewGenericWeightedCache[K comparable, V any](
+	maxWeight int64, weigher Weigher[K, V], opts ...Option,
+) GenericCache[K, V] {
+	if maxWeight <= 0 || weigher == nil {
+		return nil
+	}
+	o := newOptions(opts)
+	c := newCache(maxWeight, weigher, o)
+	if o.janitorInterval > 0 {
+		c.janitor = startJanitor(o.clock, o.janitorInterval, c.removeExpired)
+	}
+	return c
+}
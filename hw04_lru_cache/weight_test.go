@@ -0,0 +1,159 @@
+package hw04lrucache
+
+import (
+	"math/rand"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func lenWeigher(_ string, value string) int64 {
+	return int64(len(value))
+}
+
+// totalWeight суммирует вес живых элементов кэша.
+func totalWeight(c GenericCache[string, string]) int64 {
+	var total int64
+	for _, key := range c.Keys() {
+		value, _ := c.Peek(key)
+		total += lenWeigher(key, value)
+	}
+	return total
+}
+
+func TestWeightedCache(t *testing.T) {
+	t.Run("evicts until the new entry fits", func(t *testing.T) {
+		c := NewGenericWeightedCache[string, string](10, lenWeigher)
+
+		c.Set("a", "aaaa")
+		c.Set("b", "bbbb")
+		c.Set("c", "cccc") // "a" вытесняется: 12 > 10
+		require.Equal(t, []string{"c", "b"}, c.Keys())
+
+		c.Set("d", strings.Repeat("d", 9)) // нужно вытеснить оба элемента
+		require.Equal(t, []string{"d"}, c.Keys())
+
+		c.Set("e", "e")
+		require.Equal(t, []string{"e", "d"}, c.Keys())
+		require.Equal(t, int64(10), totalWeight(c))
+	})
+
+	t.Run("heavier than the budget", func(t *testing.T) {
+		c := NewGenericWeightedCache[string, string](5, lenWeigher)
+		records := make(map[string]EvictReason)
+		c.OnEvict(func(key string, _ string, reason EvictReason) {
+			records[key] = reason
+		})
+
+		c.Set("small", "ab")
+		c.Set("key", "abc")
+
+		existed, err := c.TrySet("huge", "abcdef", 0)
+		require.ErrorIs(t, err, ErrTooHeavy)
+		require.False(t, existed)
+		require.False(t, c.Set("huge", "abcdef"))
+		_, ok := c.Get("huge")
+		require.False(t, ok)
+		require.Equal(t, 2, c.Len())
+
+		// Прежнее значение по ключу не должно оставаться в кэше
+		_, err = c.TrySet("key", "abcdef", 0)
+		require.ErrorIs(t, err, ErrTooHeavy)
+		_, ok = c.Get("key")
+		require.False(t, ok)
+		require.Equal(t, map[string]EvictReason{"key": EvictCapacity}, records)
+
+		existed, err = c.TrySet("small", "abcde", 0)
+		require.NoError(t, err)
+		require.True(t, existed)
+	})
+
+	t.Run("growing update evicts other entries", func(t *testing.T) {
+		c := NewGenericWeightedCache[string, string](10, lenWeigher)
+		c.Set("a", "aaa")
+		c.Set("b", "bbb")
+		c.Set("c", "ccc")
+
+		require.True(t, c.Set("a", strings.Repeat("a", 8)))
+		require.Equal(t, []string{"a"}, c.Keys())
+
+		// Уменьшение веса освобождает место
+		require.True(t, c.Set("a", "a"))
+		c.Set("b", strings.Repeat("b", 9))
+		require.Equal(t, []string{"b", "a"}, c.Keys())
+	})
+
+	t.Run("resize limits the weight", func(t *testing.T) {
+		c := NewGenericWeightedCache[string, string](10, lenWeigher)
+		c.Set("a", "aaa")
+		c.Set("b", "bbb")
+		c.Set("c", "ccc")
+
+		require.Equal(t, 2, c.Resize(4))
+		require.Equal(t, []string{"c"}, c.Keys())
+
+		_, err := c.TrySet("d", "ddddd", 0)
+		require.ErrorIs(t, err, ErrTooHeavy)
+	})
+
+	t.Run("weights below one count as one", func(t *testing.T) {
+		c := NewWeightedCache(3, func(Key, interface{}) int64 { return -5 })
+		for i := range 5 {
+			c.Set(Key(strconv.Itoa(i)), i)
+		}
+		require.Equal(t, 3, c.Len())
+	})
+
+	t.Run("bad arguments", func(t *testing.T) {
+		require.Nil(t, NewGenericWeightedCache[string, string](0, lenWeigher))
+		require.Nil(t, NewGenericWeightedCache[string, string](10, nil))
+	})
+
+	t.Run("item count caches never reject", func(t *testing.T) {
+		c := NewCache(1)
+		existed, err := c.TrySet("k", 1, 0)
+		require.NoError(t, err)
+		require.False(t, existed)
+	})
+
+	for _, p := range allPolicies {
+		t.Run("reweighed entry is not evicted with "+p.String(), func(t *testing.T) {
+			c := NewGenericWeightedCache[string, string](10, lenWeigher, WithPolicy(p))
+			c.Set("a", "a")
+			for _, key := range []string{"b", "c", "d"} {
+				c.Set(key, "vv")
+				// Остальные элементы используются чаще, так что для LFU жертвой был бы a
+				c.Get(key)
+				c.Get(key)
+			}
+
+			require.True(t, c.Set("a", strings.Repeat("a", 9)))
+			value, ok := c.Peek("a")
+			require.True(t, ok)
+			require.Equal(t, strings.Repeat("a", 9), value)
+			require.Equal(t, []string{"a"}, c.Keys())
+		})
+	}
+
+	for _, p := range allPolicies {
+		t.Run("budget is kept with "+p.String(), func(t *testing.T) {
+			const maxWeight = 100
+			c := NewGenericWeightedCache[string, string](maxWeight, lenWeigher, WithPolicy(p))
+			rnd := rand.New(rand.NewSource(1))
+			for i := 0; i < 5_000; i++ {
+				key := strconv.Itoa(rnd.Intn(100))
+				value := strings.Repeat("v", 1+rnd.Intn(20))
+				if _, ok := c.Get(key); !ok || rnd.Intn(4) == 0 {
+					c.Set(key, value)
+				}
+				require.LessOrEqual(t, totalWeight(c), int64(maxWeight))
+			}
+			require.Positive(t, c.Len())
+
+			c.Resize(30)
+			require.LessOrEqual(t, totalWeight(c), int64(30))
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package hw05parallelexecution
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TaskError is the error returned by the task with the given index in tasks.
+type TaskError struct {
+	Index int
+	Err   error
+}
+
+func (e TaskError) Error() string {
+	return fmt.Sprintf("task %d: %v", e.Index, e.Err)
+}
+
+func (e TaskError) Unwrap() error {
+	return e.Err
+}
+
+// RunError describes failed tasks and, if the run stopped early, why. errors.Is and
+// errors.As match both its cause and the errors of failed tasks.
+type RunError struct {
+	// Cause is ErrErrorsLimitExceeded or the error of the done context, or nil if the run
+	// finished and only some tasks failed.
+	Cause error
+	// Errors holds the errors of failed tasks ordered by task index.
+	Errors []TaskError
+}
+
+func (e *RunError) Error() string {
+	var sb strings.Builder
+	if e.Cause != nil {
+		sb.WriteString(e.Cause.Error())
+	}
+	if len(e.Errors) > 0 {
+		if e.Cause != nil {
+			sb.WriteString(": ")
+		}
+		fmt.Fprintf(&sb, "%d tasks failed", len(e.Errors))
+	}
+	for i, taskErr := range e.Errors {
+		if i == 0 {
+			sb.WriteString(": ")
+		} else {
+			sb.WriteString("; ")
+		}
+		sb.WriteString(taskErr.Error())
+	}
+	return sb.String()
+}
+
+// Unwrap возвращает причину и ошибки задач, чтобы их находили errors.Is и errors.As,
+// в том числе внутри errors.Join.
+func (e *RunError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Errors)+1)
+	if e.Cause != nil {
+		errs = append(errs, e.Cause)
+	}
+	for _, taskErr := range e.Errors {
+		errs = append(errs, taskErr)
+	}
+	return errs
+}
@@ -0,0 +1,69 @@
+package hw05parallelexecution
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunError(t *testing.T) {
+	errFirst := errors.New("first")
+	errSecond := errors.New("second")
+
+	t.Run("holds failed tasks in index order", func(t *testing.T) {
+		tasks := []Task{
+			func() error { return nil },
+			func() error { return errFirst },
+			func() error { return nil },
+			func() error { return errSecond },
+		}
+
+		err := Run(tasks, 3, 2)
+
+		var runErr *RunError
+		require.ErrorAs(t, err, &runErr)
+		require.ErrorIs(t, runErr.Cause, ErrErrorsLimitExceeded)
+		require.Equal(t, []TaskError{{Index: 1, Err: errFirst}, {Index: 3, Err: errSecond}}, runErr.Errors)
+		require.Equal(t, "errors limit exceeded: 2 tasks failed: task 1: first; task 3: second", err.Error())
+	})
+
+	t.Run("works with errors.Is, errors.As and errors.Join", func(t *testing.T) {
+		var err error = &RunError{
+			Cause:  context.Canceled,
+			Errors: []TaskError{{Index: 4, Err: errFirst}},
+		}
+		joined := errors.Join(errors.New("other"), err)
+
+		require.ErrorIs(t, joined, context.Canceled)
+		require.ErrorIs(t, joined, errFirst)
+		require.NotErrorIs(t, joined, errSecond)
+
+		var taskErr TaskError
+		require.ErrorAs(t, joined, &taskErr)
+		require.Equal(t, 4, taskErr.Index)
+	})
+
+	t.Run("failures below the limit", func(t *testing.T) {
+		tasks := []ContextTask{
+			func(context.Context) error { return errFirst },
+			func(context.Context) error { return nil },
+		}
+
+		err := RunContext(context.Background(), tasks, RunOptions{Workers: 2, MaxErrors: 2})
+		var runErr *RunError
+		require.ErrorAs(t, err, &runErr)
+		require.Nil(t, runErr.Cause)
+		require.NotErrorIs(t, err, ErrErrorsLimitExceeded)
+		require.Equal(t, "1 tasks failed: task 0: first", err.Error())
+
+		// Run по-прежнему сообщает только о превышении лимита
+		require.NoError(t, Run([]Task{func() error { return errFirst }}, 1, 2))
+	})
+
+	t.Run("no task errors", func(t *testing.T) {
+		err := &RunError{Cause: context.DeadlineExceeded}
+		require.Equal(t, "context deadline exceeded", err.Error())
+	})
+}
@@ -1,9 +1,13 @@
 package hw05parallelexecution
 
 import (
+	"cmp"
+	"context"
 	"errors"
+	"slices"
 	"sync"
 	"sync/atomic"
+	"time"
 )
 
 var (
@@ -13,43 +17,164 @@ var (
 
 type Task func() error
 
+// ContextTask is a task that receives a context and should return once the context is done.
+type ContextTask func(ctx context.Context) error
+
+// RunOptions configures RunContext.
+type RunOptions struct {
+	// Workers is the number of goroutines running tasks. It must be positive.
+	Workers int
+	// MaxErrors is the number of task errors that stops the run. Non-positive means 1, as in Run.
+	MaxErrors int
+	// TaskTimeout limits the context of each task. Zero means no limit.
+	TaskTimeout time.Duration
+}
+
 // Run starts tasks in n goroutines and stops its work when receiving m errors from tasks.
+// In that case it returns a *RunError with ErrErrorsLimitExceeded as the cause;
+// fewer than m errors are not reported.
 func Run(tasks []Task, n, m int) error {
-	if n <= 0 {
+	ctxTasks := make([]ContextTask, len(tasks))
+	for i, task := range tasks {
+		ctxTasks[i] = func(context.Context) error {
+			return task()
+		}
+	}
+
+	err := RunContext(context.Background(), ctxTasks, RunOptions{Workers: n, MaxErrors: m})
+	// Run сообщает только о превышении лимита, как и до появления RunError
+	var runErr *RunError
+	if errors.As(err, &runErr) && runErr.Cause == nil {
+		return nil
+	}
+	return err
+}
+
+// RunContext is Run for tasks that receive a context. When ctx is done, no new tasks
+// are started and RunContext returns a *RunError with ctx.Err() as the cause, also if ctx
+// was done while the last tasks were running. If the errors limit was exceeded too,
+// the cause matches both ctx.Err() and ErrErrorsLimitExceeded. If tasks failed without
+// exceeding the limit, RunContext returns a *RunError with nil cause.
+// Tasks that are already running must stop on their own when their context is done.
+func RunContext(ctx context.Context, tasks []ContextTask, opts RunOptions) error {
+	if opts.Workers <= 0 {
 		return ErrWrongCountOfGoroutines
 	}
-	if m <= 0 {
-		m = 1
+	result := run(ctx, tasks, opts)
+	switch {
+	case result.cancelled && result.limitExceeded:
+		return &RunError{Cause: errors.Join(ctx.Err(), ErrErrorsLimitExceeded), Errors: result.taskErrors}
+	case result.cancelled:
+		return &RunError{Cause: ctx.Err(), Errors: result.taskErrors}
+	case result.limitExceeded:
+		return &RunError{Cause: ErrErrorsLimitExceeded, Errors: result.taskErrors}
+	case len(result.taskErrors) > 0:
+		return &RunError{Errors: result.taskErrors}
 	}
+	return nil
+}
 
-	tasksCh := make(chan Task, len(tasks))
-	for _, t := range tasks {
-		tasksCh <- t
+// ResultTask is a task that returns a value.
+type ResultTask[T any] func(ctx context.Context) (T, error)
+
+// RunResults is RunContext for tasks that return values. The result holds the value
+// of each task at its index in tasks; failed and skipped tasks leave the zero value.
+// The indices of failed tasks are listed in the returned *RunError.
+func RunResults[T any](ctx context.Context, tasks []ResultTask[T], opts RunOptions) ([]T, error) {
+	results := make([]T, len(tasks))
+	ctxTasks := make([]ContextTask, len(tasks))
+	for i, task := range tasks {
+		// Каждая задача пишет только в свою ячейку, поэтому блокировка не нужна
+		ctxTasks[i] = func(ctx context.Context) error {
+			value, err := task(ctx)
+			if err != nil {
+				return err
+			}
+			results[i] = value
+			return nil
+		}
 	}
-	close(tasksCh)
 
-	var counterErrors int32
-	var wg sync.WaitGroup
-	wg.Add(n)
+	err := RunContext(ctx, ctxTasks, opts)
+	return results, err
+}
+
+// runResult - итог выполнения задач.
+type runResult struct {
+	taskErrors    []TaskError
+	limitExceeded bool
+	cancelled     bool // Контекст завершился, пока задачи выполнялись или ждали запуска
+}
 
-	for range n {
+func run(ctx context.Context, tasks []ContextTask, opts RunOptions) runResult {
+	maxErrors := opts.MaxErrors
+	if maxErrors <= 0 {
+		maxErrors = 1
+	}
+
+	var (
+		next       atomic.Int64 // Индекс следующей задачи
+		errorCount atomic.Int32
+		cancelled  atomic.Bool
+		mutex      sync.Mutex
+		taskErrors []TaskError
+		wg         sync.WaitGroup
+	)
+	wg.Add(opts.Workers)
+
+	for range opts.Workers {
 		go func() {
 			defer wg.Done()
-			for task := range tasksCh {
-				if int(atomic.LoadInt32(&counterErrors)) >= m {
+			for {
+				// Новые задачи не выдаем после отмены контекста или превышения лимита ошибок
+				if ctx.Err() != nil {
+					// Отмена важна, только если из-за нее какая-то задача не запустилась
+					if int(next.Load()) < len(tasks) {
+						cancelled.Store(true)
+					}
+					return
+				}
+				if int(errorCount.Load()) >= maxErrors {
+					return
+				}
+				i := int(next.Add(1) - 1)
+				if i >= len(tasks) {
 					return
 				}
-				err := task()
+
+				err := runTask(ctx, tasks[i], opts.TaskTimeout)
+				// Задача застала отмену контекста и могла прерваться, не доделав работу
+				if ctx.Err() != nil {
+					cancelled.Store(true)
+				}
 				if err != nil {
-					atomic.AddInt32(&counterErrors, 1)
+					errorCount.Add(1)
+					mutex.Lock()
+					taskErrors = append(taskErrors, TaskError{Index: i, Err: err})
+					mutex.Unlock()
 				}
 			}
 		}()
 	}
 	wg.Wait()
-	if int(atomic.LoadInt32(&counterErrors)) >= m {
-		return ErrErrorsLimitExceeded
+
+	// Задачи завершаются в произвольном порядке, а ошибки удобнее читать по порядку задач
+	slices.SortFunc(taskErrors, func(a, b TaskError) int {
+		return cmp.Compare(a.Index, b.Index)
+	})
+
+	return runResult{
+		taskErrors:    taskErrors,
+		limitExceeded: int(errorCount.Load()) >= maxErrors,
+		cancelled:     cancelled.Load(),
 	}
+}
 
-	return nil
+func runTask(ctx context.Context, task ContextTask, timeout time.Duration) error {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	return task(ctx)
 }
@@ -1,6 +1,7 @@
 package hw05parallelexecution
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"math/rand"
@@ -97,11 +98,11 @@ func TestRun(t *testing.T) {
 		err := Run(tasks, -1, -1)
 		require.ErrorAs(t, err, &ErrWrongCountOfGoroutines)
 		err = Run(tasks, 5, 0)
-		require.ErrorAs(t, err, &ErrErrorsLimitExceeded)
+		require.ErrorIs(t, err, ErrErrorsLimitExceeded)
 		err = Run(tasks, 5, -1)
-		require.ErrorAs(t, err, &ErrErrorsLimitExceeded)
+		require.ErrorIs(t, err, ErrErrorsLimitExceeded)
 		err = Run(tasks, 20, 4)
-		require.ErrorAs(t, err, &ErrErrorsLimitExceeded)
+		require.ErrorIs(t, err, ErrErrorsLimitExceeded)
 		err = Run(tasks, 20, 5)
 		require.NoError(t, err)
 	})
@@ -154,3 +155,202 @@ func TestRun(t *testing.T) {
 		)
 	})
 }
+
+func TestRunContext(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	t.Run("runs all tasks", func(t *testing.T) {
+		var runTasksCount atomic.Int32
+		tasks := make([]ContextTask, 20)
+		for i := range tasks {
+			tasks[i] = func(context.Context) error {
+				runTasksCount.Add(1)
+				return nil
+			}
+		}
+
+		err := RunContext(context.Background(), tasks, RunOptions{Workers: 4})
+		require.NoError(t, err)
+		require.Equal(t, int32(len(tasks)), runTasksCount.Load())
+	})
+
+	t.Run("cancelled context stops handing out tasks", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		taskErr := errors.New("task failed")
+
+		var runTasksCount atomic.Int32
+		tasks := make([]ContextTask, 10)
+		for i := range tasks {
+			tasks[i] = func(context.Context) error {
+				if runTasksCount.Add(1) == 2 {
+					cancel()
+					return taskErr
+				}
+				return nil
+			}
+		}
+
+		err := RunContext(ctx, tasks, RunOptions{Workers: 1, MaxErrors: 5})
+		require.ErrorIs(t, err, context.Canceled)
+		require.ErrorIs(t, err, taskErr)
+		require.Equal(t, int32(2), runTasksCount.Load())
+	})
+
+	t.Run("running tasks see cancellation", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		started := make(chan struct{}, 3)
+		tasks := make([]ContextTask, 6)
+		for i := range tasks {
+			tasks[i] = func(ctx context.Context) error {
+				started <- struct{}{}
+				<-ctx.Done()
+				return ctx.Err()
+			}
+		}
+
+		go func() {
+			for range 3 {
+				<-started
+			}
+			cancel()
+		}()
+		err := RunContext(ctx, tasks, RunOptions{Workers: 3, MaxErrors: 10})
+		require.ErrorIs(t, err, context.Canceled)
+		require.Empty(t, started)
+	})
+
+	t.Run("cancellation during the final batch is reported", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		started := make(chan struct{}, 2)
+		tasks := []ContextTask{
+			func(context.Context) error { return nil },
+			func(context.Context) error { return nil },
+		}
+		// Последние задачи успевают получить все воркеры, но отмена застает их за работой
+		for range 2 {
+			tasks = append(tasks, func(ctx context.Context) error {
+				started <- struct{}{}
+				<-ctx.Done()
+				return nil
+			})
+		}
+
+		go func() {
+			<-started
+			<-started
+			cancel()
+		}()
+		err := RunContext(ctx, tasks, RunOptions{Workers: 2})
+		require.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("timeout together with errors limit", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		// Ошибки задач не оборачивают ctx.Err(), поэтому его должна содержать причина
+		errGaveUp := errors.New("gave up")
+		tasks := make([]ContextTask, 2)
+		for i := range tasks {
+			tasks[i] = func(ctx context.Context) error {
+				<-ctx.Done()
+				return errGaveUp
+			}
+		}
+
+		err := RunContext(ctx, tasks, RunOptions{Workers: 2, MaxErrors: 1})
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.ErrorIs(t, err, ErrErrorsLimitExceeded)
+	})
+
+	t.Run("context done after the run is not an error", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		err := RunContext(ctx, []ContextTask{func(context.Context) error { return nil }}, RunOptions{Workers: 1})
+		cancel()
+		require.NoError(t, err)
+	})
+
+	t.Run("task timeout", func(t *testing.T) {
+		var timedOut atomic.Int32
+		tasks := make([]ContextTask, 4)
+		for i := range tasks {
+			tasks[i] = func(ctx context.Context) error {
+				if i%2 == 0 {
+					return nil
+				}
+				<-ctx.Done()
+				timedOut.Add(1)
+				return ctx.Err()
+			}
+		}
+
+		err := RunContext(context.Background(), tasks, RunOptions{
+			Workers: 2, MaxErrors: 2, TaskTimeout: 10 * time.Millisecond,
+		})
+		require.ErrorIs(t, err, ErrErrorsLimitExceeded)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.Equal(t, int32(2), timedOut.Load())
+	})
+
+	t.Run("errors limit exceeded", func(t *testing.T) {
+		taskErr := errors.New("task failed")
+		tasks := []ContextTask{
+			func(context.Context) error { return taskErr },
+			func(context.Context) error { return nil },
+		}
+
+		err := RunContext(context.Background(), tasks, RunOptions{Workers: 1})
+		require.ErrorIs(t, err, ErrErrorsLimitExceeded)
+		require.ErrorIs(t, err, taskErr)
+	})
+
+	t.Run("incorrect count of goroutines", func(t *testing.T) {
+		err := RunContext(context.Background(), nil, RunOptions{})
+		require.ErrorIs(t, err, ErrWrongCountOfGoroutines)
+	})
+}
+
+func TestRunResults(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	t.Run("results in input order", func(t *testing.T) {
+		tasks := make([]ResultTask[string], 20)
+		for i := range tasks {
+			tasks[i] = func(context.Context) (string, error) {
+				time.Sleep(time.Millisecond * time.Duration(rand.Intn(5)))
+				return fmt.Sprint(i), nil
+			}
+		}
+
+		results, err := RunResults(context.Background(), tasks, RunOptions{Workers: 5})
+		require.NoError(t, err)
+		for i, result := range results {
+			require.Equal(t, fmt.Sprint(i), result)
+		}
+	})
+
+	t.Run("failed tasks leave zero values", func(t *testing.T) {
+		errTask := errors.New("task failed")
+		tasks := []ResultTask[int]{
+			func(context.Context) (int, error) { return 1, nil },
+			func(context.Context) (int, error) { return 2, errTask },
+			func(context.Context) (int, error) { return 3, nil },
+		}
+
+		// Ошибки ниже лимита не останавливают выполнение, но сообщаются с индексами задач
+		results, err := RunResults(context.Background(), tasks, RunOptions{Workers: 1, MaxErrors: 2})
+		var runErr *RunError
+		require.ErrorAs(t, err, &runErr)
+		require.NoError(t, runErr.Cause)
+		require.NotErrorIs(t, err, ErrErrorsLimitExceeded)
+		require.ErrorIs(t, err, errTask)
+		require.Equal(t, []TaskError{{Index: 1, Err: errTask}}, runErr.Errors)
+		require.Equal(t, []int{1, 0, 3}, results)
+
+		results, err = RunResults(context.Background(), tasks, RunOptions{Workers: 1, MaxErrors: 1})
+		require.ErrorAs(t, err, &runErr)
+		require.ErrorIs(t, err, ErrErrorsLimitExceeded)
+		require.Equal(t, []TaskError{{Index: 1, Err: errTask}}, runErr.Errors)
+		require.Equal(t, []int{1, 0, 0}, results)
+	})
+}